@@ -27,9 +27,12 @@ type WalkFunc func(advance int, curRune rune, curSGREsc string) error
 // run WalkFunc either on each rune that is not part of an escape sequence or
 // on each ANSI escape sequence.
 // Walk stops and returns any error raised by fn (that is not ErrStopWalk).
+//
+// Operating System Commands (OSC, format ESC]...BEL or ESC]...ESC\\, like
+// hyperlinks) are considered as escape sequences too.
 func Walk(p []byte, fn WalkFunc) (err error) {
 	advance := 0
-	inEscSeq := false
+	inEscSeq, inOSC := false, false
 	curEsc := new(bytes.Buffer)
 
 	for len(p[advance:]) > 0 {
@@ -37,10 +40,22 @@ func Walk(p []byte, fn WalkFunc) (err error) {
 		advance += sz
 
 		switch {
+		case inOSC:
+			curEsc.WriteRune(c)
+			if c == '\a' || bytes.HasSuffix(curEsc.Bytes(), []byte(cST)) {
+				err = fn(advance, -1, curEsc.String())
+				inOSC = false
+				curEsc.Reset()
+			}
+
 		case c == '\x1b':
 			inEscSeq = true
 			curEsc.WriteRune(c)
 
+		case inEscSeq && c == ']' && curEsc.Len() == 1:
+			inEscSeq, inOSC = false, true
+			curEsc.WriteRune(c)
+
 		case inEscSeq:
 			curEsc.WriteRune(c)
 			if unicode.IsLetter(c) || c == '~' {
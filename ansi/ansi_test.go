@@ -21,6 +21,11 @@ func TestWalk(t *testing.T) {
 			want:    "Bonjour, tout le monde !",
 			wantSGR: Sequence{cReset, cFaint},
 		},
+		{
+			in:      "\x1b]8;;https://example.com\x1b\\Bonjour\x1b]8;;\x1b\\ \x1b]8;;https://example.com\amonde\x1b]8;;\a",
+			want:    "Bonjour monde",
+			wantSGR: nil,
+		},
 	}
 
 	for _, tc := range testCases {
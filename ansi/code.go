@@ -9,6 +9,8 @@ type Code = string
 
 const (
 	cCSI = "\x1b["
+	cOSC = "\x1b]"
+	cST  = "\x1b\\"
 
 	cReset      Code = "0"
 	cBold       Code = "1"
@@ -58,6 +60,9 @@ const (
 	cNotFramed    Code = "54"
 	cNotOverlined Code = "55"
 
+	cSetUnderlineColor     Code = "58"
+	cDefaultUnderlineColor Code = "59"
+
 	cBrightBlack   Code = "90"
 	cBrightRed     Code = "91"
 	cBrightGreen   Code = "92"
@@ -82,7 +87,7 @@ const (
 func isFGColor(c Code) bool {
 	return c == cBlack || c == cRed || c == cGreen || c == cYellow || c == cBlue || c == cMagenta || c == cCyan || c == cWhite ||
 		c == cBrightBlack || c == cBrightRed || c == cBrightGreen || c == cBrightYellow || c == cBrightBlue || c == cBrightMagenta || c == cBrightCyan || c == cBrightWhite ||
-		c == cSetFGColor || c == cDefaultFG
+		c == cSetFGColor || c == cDefaultFG || strings.HasPrefix(c, cSetFGColor+";")
 }
 
 // isBGColor is true if the corresponding Code allows to modify back-ground
@@ -90,7 +95,19 @@ func isFGColor(c Code) bool {
 func isBGColor(c Code) bool {
 	return c == cBlackBG || c == cRedBG || c == cGreenBG || c == cYellowBG || c == cBlueBG || c == cMagentaBG || c == cCyanBG || c == cWhiteBG ||
 		c == cBrightBlackBG || c == cBrightRedBG || c == cBrightGreenBG || c == cBrightYellowBG || c == cBrightBlueBG || c == cBrightMagentaBG || c == cBrightCyanBG || c == cBrightWhiteBG ||
-		c == cSetBGColor || c == cDefaultBG
+		c == cSetBGColor || c == cDefaultBG || strings.HasPrefix(c, cSetBGColor+";")
+}
+
+// isUnderlineColor is true if the corresponding Code allows to modify
+// underline colors.
+func isUnderlineColor(c Code) bool {
+	return c == cSetUnderlineColor || c == cDefaultUnderlineColor || strings.HasPrefix(c, cSetUnderlineColor+";")
+}
+
+// isUnderline is true if the corresponding Code underlines text, whatever the
+// underline style.
+func isUnderline(c Code) bool {
+	return c == cUnderline || strings.HasPrefix(c, cUnderline+":")
 }
 
 // isStyleOff is true if the corresponding Code allows to set off graphic
@@ -101,7 +118,7 @@ func isStyleOff(c Code) bool {
 	return c == cBoldOff || c == cNormal || c == cItalicOff ||
 		c == cUnderlineOff || c == cBlinkOff || c == cInverseOff ||
 		c == cReveal || c == cNotCrossedOut || c == cNotFramed || c == cNotOverlined ||
-		c == cDefaultFG || c == cDefaultBG
+		c == cDefaultFG || c == cDefaultBG || c == cDefaultUnderlineColor
 }
 
 // isSupersededBy is true if the corresponding Code visual effect is supeseded
@@ -111,10 +128,12 @@ func isSupersededBy(ca, cb Code) bool {
 	return cb == cReset ||
 		(isFGColor(cb) && isFGColor(ca)) ||
 		(isBGColor(cb) && isBGColor(ca)) ||
+		(isUnderlineColor(cb) && isUnderlineColor(ca)) ||
+		(isUnderline(cb) && isUnderline(ca)) ||
 		(cb == cBoldOff && ca == cBold) ||
 		(cb == cNormal && (ca == cBold || ca == cFaint)) ||
 		(cb == cItalicOff && ca == cItalic) ||
-		(cb == cUnderlineOff && ca == cUnderline) ||
+		(cb == cUnderlineOff && isUnderline(ca)) ||
 		(cb == cBlinkOff && (ca == cSlowBlink || ca == cRapidBlink)) ||
 		(cb == cInverseOff && ca == cInverse) ||
 		(cb == cReveal && ca == cConceal) ||
@@ -198,6 +217,10 @@ func (seq *Sequence) add(c Code) {
 // ParseSGR parses an ANSI escape sequence  of SGR (Set Graphic Rendition,
 // format ESC[code;..;codem) parameters into a slice of ansi.Code.
 //
+// ParseSGR understands colon-separated sub-parameters (like ESC[4:3m for a
+// curly underline or ESC[38:2::r:g:bm for a 24bit color). Colors expressed
+// with sub-parameters are normalized to their semicolon-separated form.
+//
 // ParseSGR returns nil if supplied Escape Sequence does not look slike an SGR
 // sequence (does not start by '\x1b[' nor ends by 'm').
 //
@@ -218,34 +241,83 @@ func ParseSGR(esc string) Sequence {
 
 	var s Sequence
 	for i := 0; i < len(codes); i++ {
-		switch c := codes[i]; c {
-		case "":
+		switch c := codes[i]; {
+		case c == "":
 			s = append(s, cReset)
 
-		case cSetFGColor, cSetBGColor:
-			if i == len(codes)-1 {
-				break
+		case strings.Contains(c, ":"):
+			if c = parseSubParams(c); c != "" {
+				s = append(s, c)
 			}
-			switch codes[i+1] {
-			case "5":
-				if i == len(codes)-2 {
-					break
-				}
+
+		case c == cSetFGColor || c == cSetBGColor || c == cSetUnderlineColor:
+			// incomplete color specifications are dropped as well as any
+			// remaining parameters whose meaning cannot be trusted.
+			switch {
+			case i+2 < len(codes) && codes[i+1] == "5":
 				s, i = append(s, c+";5;"+codes[i+2]), i+2
-			case "2":
-				if i == len(codes)-4 {
-					break
-				}
+			case i+4 < len(codes) && codes[i+1] == "2":
 				s, i = append(s, c+";2;"+codes[i+2]+";"+codes[i+3]+";"+codes[i+4]), i+4
+			default:
+				i = len(codes)
 			}
 
 		default:
-			s = append(s, c)
+			s = append(s, normalizeCode(c))
 		}
 	}
 	return s
 }
 
+// normalizeCode strips leading zeros from numeric Codes (like "01" that is
+// commonly found in dircolors's configurations).
+func normalizeCode(c Code) Code {
+	if len(c) > 1 && c[0] == '0' && strings.Trim(c, "0123456789") == "" {
+		if c = strings.TrimLeft(c, "0"); c == "" {
+			return cReset
+		}
+	}
+	return c
+}
+
+// parseSubParams parses an SGR parameter made of colon-separated
+// sub-parameters. It returns an empty Code if the parameter is not
+// understood.
+func parseSubParams(param string) Code {
+	p := strings.Split(param, ":")
+
+	switch p[0] {
+	case cUnderline:
+		if len(p) != 2 {
+			return ""
+		}
+		switch p[1] {
+		case "0":
+			return cUnderlineOff
+		case "1", "":
+			return cUnderline
+		}
+		return cUnderline + ":" + p[1]
+
+	case cSetFGColor, cSetBGColor, cSetUnderlineColor:
+		if len(p) < 3 {
+			return ""
+		}
+		switch {
+		case p[1] == "5":
+			return p[0] + ";5;" + p[2]
+		case p[1] == "2" && len(p) == 5:
+			return p[0] + ";2;" + strings.Join(p[2:], ";")
+		case p[1] == "2" && len(p) >= 6:
+			// p[2] is the (usually empty) color space identifier
+			return p[0] + ";2;" + strings.Join(p[3:6], ";")
+		}
+		return ""
+	}
+
+	return param
+}
+
 // Combine merges two ANSI sequences. It deletes existing Codes that are
 // superseded by new ones (like a Red by a Green) or as well as Codes that are
 // not useful at the boundaries of the SGRSequence (like BoldOff without a
@@ -17,6 +17,14 @@ func TestParseSGR(t *testing.T) {
 		{in: cCSI + "1;;m", want: Sequence{cBold, cReset, cReset}},
 		{in: cCSI + "48;5;1;32m", want: Sequence{"48;5;1", cGreen}},
 		{in: cCSI + "38;2;1;2;3;32m", want: Sequence{"38;2;1;2;3", cGreen}},
+		{in: cCSI + "38;2;1m", want: nil},
+		{in: cCSI + "4:3m", want: Sequence{"4:3"}},
+		{in: cCSI + "4:0;4:1m", want: Sequence{cUnderlineOff, cUnderline}},
+		{in: cCSI + "58;2;1;2;3m", want: Sequence{"58;2;1;2;3"}},
+		{in: cCSI + "58:5:208;59m", want: Sequence{"58;5;208", cDefaultUnderlineColor}},
+		{in: cCSI + "1;38:2::1:2:3m", want: Sequence{cBold, "38;2;1;2;3"}},
+		{in: cCSI + "48:2:1:2:3m", want: Sequence{"48;2;1;2;3"}},
+		{in: cCSI + "01;00;34m", want: Sequence{cBold, cReset, cBlue}},
 		{in: cCSI + "A", want: nil},
 	}
 
@@ -52,6 +60,16 @@ func TestAdd(t *testing.T) {
 			[]Code{cRed, cBold, cBlue, cNormal},
 			Sequence{cBlue},
 		},
+
+		{
+			[]Code{cUnderline, "4:3", "58;5;1", "58;2;1;2;3"},
+			Sequence{"4:3", "58;2;1;2;3"},
+		},
+
+		{
+			[]Code{cRed, "4:3", "58;5;1", cUnderlineOff, cDefaultUnderlineColor},
+			Sequence{cRed},
+		},
 	}
 
 	for _, tc := range testCases {
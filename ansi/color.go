@@ -0,0 +1,239 @@
+package ansi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type colorKind uint8
+
+const (
+	unsetColor colorKind = iota
+	defaultColor
+	namedColor
+	indexedColor
+	rgbColor
+)
+
+// Color represents a terminal color. A Color is either one of the 16 standard
+// named colors, a color of the 8bit colors palette (256 colors), a 24bit
+// color (R,G,B colors) or the terminal's default color.
+//
+// The zero value of Color represents an unset Color that does not modify
+// terminal's current color.
+type Color struct {
+	kind    colorKind
+	idx     uint8
+	r, g, b uint8
+}
+
+// The 16 standard named colors and the terminal's default color.
+var (
+	ColorBlack   = Color{kind: namedColor, idx: 0}
+	ColorRed     = Color{kind: namedColor, idx: 1}
+	ColorGreen   = Color{kind: namedColor, idx: 2}
+	ColorYellow  = Color{kind: namedColor, idx: 3}
+	ColorBlue    = Color{kind: namedColor, idx: 4}
+	ColorMagenta = Color{kind: namedColor, idx: 5}
+	ColorCyan    = Color{kind: namedColor, idx: 6}
+	ColorWhite   = Color{kind: namedColor, idx: 7}
+
+	ColorBrightBlack   = Color{kind: namedColor, idx: 8}
+	ColorBrightRed     = Color{kind: namedColor, idx: 9}
+	ColorBrightGreen   = Color{kind: namedColor, idx: 10}
+	ColorBrightYellow  = Color{kind: namedColor, idx: 11}
+	ColorBrightBlue    = Color{kind: namedColor, idx: 12}
+	ColorBrightMagenta = Color{kind: namedColor, idx: 13}
+	ColorBrightCyan    = Color{kind: namedColor, idx: 14}
+	ColorBrightWhite   = Color{kind: namedColor, idx: 15}
+
+	ColorDefault = Color{kind: defaultColor}
+)
+
+var colorNames = [16]string{
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+	"brightblack", "brightred", "brightgreen", "brightyellow", "brightblue", "brightmagenta", "brightcyan", "brightwhite",
+}
+
+// Indexed returns the idx-th Color of the 8bit colors palette (256 colors).
+func Indexed(idx uint8) Color {
+	return Color{kind: indexedColor, idx: idx}
+}
+
+// RGB returns a 24bit Color.
+func RGB(r, g, b uint8) Color {
+	return Color{kind: rgbColor, r: r, g: g, b: b}
+}
+
+// ParseColor parses a Color from its textual representation. It understands
+// named colors ("red", "bright-red", "brightred"), 8bit colors palette index
+// ("208"), hexadecimal 24bit colors ("#ff8800" or "#f80") and "default".
+func ParseColor(s string) (Color, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+
+	switch {
+	case name == "default":
+		return ColorDefault, nil
+
+	case strings.HasPrefix(name, "#"):
+		return parseHexColor(name[1:], s)
+
+	case name != "" && strings.Trim(name, "0123456789") == "":
+		idx, err := strconv.ParseUint(name, 10, 8)
+		if err != nil {
+			return Color{}, fmt.Errorf("invalid color index '%s'", s)
+		}
+		return Indexed(uint8(idx)), nil
+	}
+
+	name = strings.NewReplacer("-", "", "_", "", " ", "").Replace(name)
+	for i, n := range colorNames {
+		if n == name {
+			return Color{kind: namedColor, idx: uint8(i)}, nil
+		}
+	}
+
+	return Color{}, fmt.Errorf("invalid color '%s'", s)
+}
+
+func parseHexColor(hex string, s string) (Color, error) {
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	if len(hex) != 6 {
+		return Color{}, fmt.Errorf("invalid hexadecimal color '%s'", s)
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("invalid hexadecimal color '%s'", s)
+	}
+
+	return RGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+}
+
+// IsZero is true if Color is not set.
+func (c Color) IsZero() bool {
+	return c.kind == unsetColor
+}
+
+// String returns the textual representation of the Color, as understood by
+// ParseColor.
+func (c Color) String() string {
+	switch c.kind {
+	case defaultColor:
+		return "default"
+	case namedColor:
+		return colorNames[c.idx]
+	case indexedColor:
+		return strconv.Itoa(int(c.idx))
+	case rgbColor:
+		return fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b)
+	}
+
+	return ""
+}
+
+// RGB returns the 24bit equivalent of the Color. Standard named colors are
+// converted using xterm's default values. The terminal's default color as
+// well as unset Color are converted to black.
+func (c Color) RGB() (r, g, b uint8) {
+	switch c.kind {
+	case namedColor, indexedColor:
+		v := palette256(c.idx)
+		return v.r, v.g, v.b
+	case rgbColor:
+		return c.r, c.g, c.b
+	}
+
+	return 0, 0, 0
+}
+
+// FG returns the Code that sets the Color as foreground color.
+func (c Color) FG() Code {
+	return c.code(cSetFGColor, "3", "9", cDefaultFG)
+}
+
+// BG returns the Code that sets the Color as background color.
+func (c Color) BG() Code {
+	return c.code(cSetBGColor, "4", "10", cDefaultBG)
+}
+
+func (c Color) code(set Code, normal, bright string, dflt Code) Code {
+	switch c.kind {
+	case defaultColor:
+		return dflt
+	case namedColor:
+		if c.idx < 8 {
+			return normal + strconv.Itoa(int(c.idx))
+		}
+		return bright + strconv.Itoa(int(c.idx-8))
+	case indexedColor:
+		return set + ";5;" + strconv.Itoa(int(c.idx))
+	case rgbColor:
+		return set + ";2;" + strconv.Itoa(int(c.r)) + ";" + strconv.Itoa(int(c.g)) + ";" + strconv.Itoa(int(c.b))
+	}
+
+	return ""
+}
+
+// underlineCode returns the Code that sets the Color as underline color.
+// Underline colors do not support the standard named colors codes so that
+// they are selected using their 8bit colors palette index.
+func (c Color) underlineCode() Code {
+	if c.kind == namedColor {
+		return Indexed(c.idx).code(cSetUnderlineColor, "", "", "")
+	}
+	return c.code(cSetUnderlineColor, "", "", cDefaultUnderlineColor)
+}
+
+// colorFromCode returns the Color selected by a Code that sets either the
+// foreground, the background or the underline color.
+func colorFromCode(c Code) (Color, bool) {
+	switch {
+	case c == cDefaultFG || c == cDefaultBG || c == cDefaultUnderlineColor:
+		return ColorDefault, true
+	case c == cSetFGColor || c == cSetBGColor || c == cSetUnderlineColor:
+		return Color{}, false
+	}
+
+	params := strings.Split(c, ";")
+	if len(params) == 1 {
+		n, err := strconv.Atoi(c)
+		if err != nil {
+			return Color{}, false
+		}
+
+		switch {
+		case n >= 30 && n <= 37:
+			return Color{kind: namedColor, idx: uint8(n - 30)}, true
+		case n >= 40 && n <= 47:
+			return Color{kind: namedColor, idx: uint8(n - 40)}, true
+		case n >= 90 && n <= 97:
+			return Color{kind: namedColor, idx: uint8(n - 90 + 8)}, true
+		case n >= 100 && n <= 107:
+			return Color{kind: namedColor, idx: uint8(n - 100 + 8)}, true
+		}
+		return Color{}, false
+	}
+
+	var v []uint8
+	for _, p := range params[2:] {
+		n, err := strconv.ParseUint(p, 10, 8)
+		if err != nil {
+			return Color{}, false
+		}
+		v = append(v, uint8(n))
+	}
+
+	switch {
+	case params[1] == "5" && len(v) == 1:
+		return Indexed(v[0]), true
+	case params[1] == "2" && len(v) == 3:
+		return RGB(v[0], v[1], v[2]), true
+	}
+
+	return Color{}, false
+}
@@ -0,0 +1,69 @@
+package ansi
+
+import (
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want Color
+	}{
+		{"red", ColorRed},
+		{"Bright-Red", ColorBrightRed},
+		{"brightred", ColorBrightRed},
+		{"default", ColorDefault},
+		{"208", Indexed(208)},
+		{"#ff8800", RGB(0xff, 0x88, 0x00)},
+		{"#F80", RGB(0xff, 0x88, 0x00)},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseColor(tc.in)
+		if err != nil {
+			t.Errorf("Fail to parse color %#v: %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Fail to parse color %#v.\nWant: %#v\nGot : %#v", tc.in, tc.want, got)
+		}
+
+		if rt, _ := ParseColor(got.String()); rt != got {
+			t.Errorf("Fail to round-trip color %#v.\nWant: %#v\nGot : %#v", tc.in, got, rt)
+		}
+	}
+
+	for _, in := range []string{"", "300", "abc", "#ff88", "#gg8800", "brightdefault"} {
+		if got, err := ParseColor(in); err == nil {
+			t.Errorf("Parsing color %#v should fail but got %#v", in, got)
+		}
+	}
+}
+
+func TestColorCode(t *testing.T) {
+	testCases := []struct {
+		in         Color
+		wantFG     Code
+		wantBG     Code
+		wantUnderl Code
+	}{
+		{ColorRed, "31", "41", "58;5;1"},
+		{ColorBrightCyan, "96", "106", "58;5;14"},
+		{ColorDefault, "39", "49", "59"},
+		{Indexed(208), "38;5;208", "48;5;208", "58;5;208"},
+		{RGB(1, 2, 3), "38;2;1;2;3", "48;2;1;2;3", "58;2;1;2;3"},
+		{Color{}, "", "", ""},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.in.FG(); got != tc.wantFG {
+			t.Errorf("Fail to get foreground code of %v.\nWant: %#v\nGot : %#v", tc.in, tc.wantFG, got)
+		}
+		if got := tc.in.BG(); got != tc.wantBG {
+			t.Errorf("Fail to get background code of %v.\nWant: %#v\nGot : %#v", tc.in, tc.wantBG, got)
+		}
+		if got := tc.in.underlineCode(); got != tc.wantUnderl {
+			t.Errorf("Fail to get underline code of %v.\nWant: %#v\nGot : %#v", tc.in, tc.wantUnderl, got)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package ansi
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// LSColors maps LS_COLORS (dircolors) keys (like "di", "ln" or "*.tar") to
+// their Sequence.
+type LSColors map[string]Sequence
+
+// ParseLSColors parses the content of the LS_COLORS environment variable, as
+// generated by dircolors (for example "di=01;34:ln=01;36:*.tar=01;31").
+//
+// Entries whose value is "target" (like "ln=target" that asks for symbolic
+// links to be colored like the file they point to) and file name patterns
+// that are not valid path.Match patterns are ignored.
+func ParseLSColors(s string) (LSColors, error) {
+	m, err := parseColorList(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LS_COLORS: %v", err)
+	}
+
+	for key := range m {
+		if _, err := path.Match(key, ""); err != nil {
+			delete(m, key)
+		}
+	}
+
+	return LSColors(m), nil
+}
+
+// Match returns the Sequence that corresponds to a file name according to
+// LS_COLORS's file name patterns (like "*.tar"). Match returns nil if no
+// pattern matches. When several patterns match, the longest one wins, ties
+// being resolved by choosing the first pattern in lexical order so that the
+// result does not depend on the map's iteration order.
+func (c LSColors) Match(filename string) Sequence {
+	var best string
+	for pattern := range c {
+		if !strings.HasPrefix(pattern, "*") {
+			continue
+		}
+		if len(pattern) < len(best) || (len(pattern) == len(best) && pattern > best) {
+			continue
+		}
+
+		ok, err := path.Match(pattern, filename)
+		if err != nil {
+			// invalid patterns never match.
+			continue
+		}
+		if ok {
+			best = pattern
+		}
+	}
+
+	if best == "" {
+		return nil
+	}
+	return c[best]
+}
+
+// ParseGrepColors parses the content of the GREP_COLORS environment variable
+// (for example "ms=01;31:fn=35:ln=32:ne").
+// Boolean capabilities (like "rv" or "ne") are present in the returned map
+// with a nil Sequence.
+func ParseGrepColors(s string) (map[string]Sequence, error) {
+	m, err := parseColorList(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GREP_COLORS: %v", err)
+	}
+	return m, nil
+}
+
+// parseColorList parses a colon-separated list of 'key=SGR parameters'
+// entries.
+func parseColorList(s string) (map[string]Sequence, error) {
+	m := make(map[string]Sequence)
+
+	for _, entry := range strings.Split(s, ":") {
+		if entry == "" {
+			continue
+		}
+
+		kv := strings.SplitN(entry, "=", 2)
+		if kv[0] == "" {
+			return nil, fmt.Errorf("missing key in '%s'", entry)
+		}
+
+		if len(kv) == 1 || kv[1] == "" {
+			m[kv[0]] = nil
+			continue
+		}
+
+		// dircolors' "target" value is not a color but asks to use the
+		// color of a symbolic link's target.
+		if kv[1] == "target" {
+			continue
+		}
+
+		if strings.Trim(kv[1], "0123456789;") != "" {
+			return nil, fmt.Errorf("invalid SGR parameters in '%s'", entry)
+		}
+		m[kv[0]] = ParseSGR(cCSI + kv[1] + "m")
+	}
+
+	return m, nil
+}
+
+var gitAttributes = map[string]Code{
+	"reset":   cReset,
+	"bold":    cBold,
+	"dim":     cFaint,
+	"italic":  cItalic,
+	"ul":      cUnderline,
+	"blink":   cSlowBlink,
+	"reverse": cInverse,
+	"strike":  cCrossedOut,
+
+	"nobold":    cNormal,
+	"nodim":     cNormal,
+	"noitalic":  cItalicOff,
+	"noul":      cUnderlineOff,
+	"noblink":   cBlinkOff,
+	"noreverse": cInverseOff,
+	"nostrike":  cNotCrossedOut,
+}
+
+// ParseGitColor parses a color value using git's configuration syntax (like
+// "bold red ul #ff0000" or "normal blue").
+//
+// As for git, the first color is the foreground color, the second one the
+// background color and "normal" stands for an unchanged color. Attributes can
+// be negated using "no" or "no-" prefixes.
+func ParseGitColor(s string) (Sequence, error) {
+	var seq, colors Sequence
+	var nColors int
+
+	for _, word := range strings.Fields(strings.ToLower(s)) {
+		if a, ok := gitAttributes[strings.Replace(word, "no-", "no", 1)]; ok {
+			if a == cReset {
+				seq = append(Sequence{cReset}, seq...)
+			} else {
+				seq = append(seq, a)
+			}
+			continue
+		}
+
+		if nColors == 2 {
+			return nil, fmt.Errorf("invalid git color '%s': too many colors", s)
+		}
+		nColors++
+
+		if word == "normal" {
+			continue
+		}
+
+		c, err := ParseColor(word)
+		if err != nil {
+			return nil, fmt.Errorf("invalid git color '%s': %v", s, err)
+		}
+
+		if nColors == 1 {
+			colors = append(colors, c.FG())
+		} else {
+			colors = append(colors, c.BG())
+		}
+	}
+
+	return append(seq, colors...), nil
+}
@@ -0,0 +1,89 @@
+package ansi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLSColors(t *testing.T) {
+	got, err := ParseLSColors("rs=0:di=01;34:ln=target:or=01;36:mi=:*.tar=01;31:*.tar.gz=38;5;208:*.gz=01;35:*.?z=01;33:*[=01;32:")
+	if err != nil {
+		t.Fatalf("Fail to parse LS_COLORS: %v", err)
+	}
+
+	want := LSColors{
+		"rs":       {cReset},
+		"di":       {cBold, cBlue},
+		"or":       {cBold, cCyan},
+		"mi":       nil,
+		"*.tar":    {cBold, cRed},
+		"*.tar.gz": {"38;5;208"},
+		"*.gz":     {cBold, cMagenta},
+		"*.?z":     {cBold, cYellow},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Fail to parse LS_COLORS.\nWant: %#v\nGot : %#v", want, got)
+	}
+
+	testCases := []struct {
+		in   string
+		want Sequence
+	}{
+		{"archive.tar", Sequence{cBold, cRed}},
+		{"archive.tar.gz", Sequence{"38;5;208"}},
+		{"archive.zip", nil},
+		{"archive.gz", Sequence{cBold, cYellow}},
+		{"archive.xz", Sequence{cBold, cYellow}},
+	}
+	for _, tc := range testCases {
+		if got := got.Match(tc.in); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Fail to match LS_COLORS for %#v.\nWant: %#v\nGot : %#v", tc.in, tc.want, got)
+		}
+	}
+
+	if _, err := ParseLSColors("di=bold"); err == nil {
+		t.Errorf("Parsing invalid LS_COLORS should fail")
+	}
+}
+
+func TestParseGrepColors(t *testing.T) {
+	got, err := ParseGrepColors("ms=01;31:fn=35:ne")
+	if err != nil {
+		t.Fatalf("Fail to parse GREP_COLORS: %v", err)
+	}
+
+	want := map[string]Sequence{"ms": {cBold, cRed}, "fn": {"35"}, "ne": nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Fail to parse GREP_COLORS.\nWant: %#v\nGot : %#v", want, got)
+	}
+}
+
+func TestParseGitColor(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want Sequence
+	}{
+		{"red", Sequence{cRed}},
+		{"bold red ul #ff0000", Sequence{cBold, cUnderline, cRed, "48;2;255;0;0"}},
+		{"normal blue", Sequence{cBlueBG}},
+		{"brightred 208 no-bold", Sequence{cNormal, cBrightRed, "48;5;208"}},
+		{"bold reset default", Sequence{cReset, cBold, cDefaultFG}},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseGitColor(tc.in)
+		if err != nil {
+			t.Errorf("Fail to parse git color %#v: %v", tc.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Fail to parse git color %#v.\nWant: %#v\nGot : %#v", tc.in, tc.want, got)
+		}
+	}
+
+	for _, in := range []string{"red blue green", "bolder"} {
+		if got, err := ParseGitColor(in); err == nil {
+			t.Errorf("Parsing git color %#v should fail but got %#v", in, got)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package ansi_test
 
 import (
 	"fmt"
+	"strings"
+	"text/template"
 
 	"github.com/pirmd/text/ansi"
 )
@@ -45,3 +47,20 @@ func ExampleWalkString_second() {
 	//Output:
 	//Bonjour tout le monde !
 }
+
+func ExampleMarkup() {
+	s, _ := ansi.Markup("[bold]Have [green]fun[/] with [blue on white]Colors[/][/]")
+	fmt.Printf("%q", s)
+	//Output:
+	//"\x1b[1mHave \x1b[32mfun\x1b[39m with \x1b[34;47mColors\x1b[0m"
+}
+
+func ExampleMarkup_template() {
+	tmpl := template.Must(template.New("hello").Funcs(ansi.FuncMap()).Parse(`{{ Markup "[red]Bonjour[/] [bold]tout[/] le monde !" }}`))
+
+	var s strings.Builder
+	_ = tmpl.Execute(&s, nil)
+	fmt.Printf("%q", s.String())
+	//Output:
+	//"\x1b[31mBonjour\x1b[0m \x1b[1mtout\x1b[0m le monde !"
+}
@@ -0,0 +1,97 @@
+package ansi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markup renders a text that contains inline style markup into a text
+// decorated with ANSI escape sequences.
+//
+// A '[style]' tag applies the style (following ParseStyle's syntax) to the
+// text until the corresponding '[/]' closing tag. Tags can be nested, the
+// closing tag restoring the enclosing style:
+//
+//	[bold]Hello [red]beautiful[/] world[/]!
+//
+// A literal '[' is obtained by doubling it ('[[').
+// Tags that are not closed at the end of the text are automatically closed.
+func Markup(s string) (string, error) {
+	return Theme(nil).Markup(s)
+}
+
+// Markup renders a text that contains inline style markup into a text
+// decorated with ANSI escape sequences. It works like ansi.Markup but tags
+// can also refer to the Theme's style names (like '[error]oops[/]').
+func (t Theme) Markup(s string) (string, error) {
+	var out strings.Builder
+	var cur Style
+	stack := []Style{{}}
+
+	// write outputs text after having applied the expected style. Applying
+	// styles is delayed up to this point so that consecutive tags only
+	// produce one escape sequence.
+	write := func(text string) {
+		if text == "" {
+			return
+		}
+		out.WriteString(Transition(cur, stack[len(stack)-1]))
+		cur = stack[len(stack)-1]
+		out.WriteString(text)
+	}
+
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '[')
+		if i < 0 {
+			write(s)
+			break
+		}
+
+		write(s[:i])
+		s = s[i:]
+
+		if strings.HasPrefix(s, "[[") {
+			write("[")
+			s = s[2:]
+			continue
+		}
+
+		j := strings.IndexByte(s, ']')
+		if j < 0 {
+			return "", fmt.Errorf("unterminated markup tag '%s'", s)
+		}
+		tag := strings.TrimSpace(s[1:j])
+		s = s[j+1:]
+
+		if tag == "/" {
+			if len(stack) == 1 {
+				return "", fmt.Errorf("markup closing tag without opening tag")
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		style, err := t.lookup(tag)
+		if err != nil {
+			return "", err
+		}
+		stack = append(stack, stack[len(stack)-1].Merge(style))
+	}
+
+	out.WriteString(Transition(cur, Style{}))
+	return out.String(), nil
+}
+
+// lookup returns the Style corresponding to a markup tag, either from the
+// Theme's Style names or by parsing it.
+func (t Theme) lookup(tag string) (Style, error) {
+	if style, ok := t[tag]; ok {
+		return style, nil
+	}
+
+	if tag == "" {
+		return Style{}, fmt.Errorf("empty markup tag")
+	}
+
+	return ParseStyle(tag)
+}
@@ -0,0 +1,38 @@
+package ansi
+
+import (
+	"testing"
+)
+
+func TestMarkup(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{"Hello world!", "Hello world!"},
+		{"[red]Hello[/] world!", "\x1b[31mHello\x1b[0m world!"},
+		{"[bold]Hello [red]beautiful[/] world[/]!", "\x1b[1mHello \x1b[31mbeautiful\x1b[39m world\x1b[0m!"},
+		{"[faint]Hello [bold]beautiful[/] world[/]!", "\x1b[2mHello \x1b[1mbeautiful\x1b[0;2m world\x1b[0m!"},
+		{"[red]Hello [green]beautiful[/] world", "\x1b[31mHello \x1b[32mbeautiful\x1b[31m world\x1b[0m"},
+		{"array[[0] = 1", "array[0] = 1"},
+		{"[error]Oops[/]", "\x1b[1;91mOops\x1b[0m"},
+	}
+
+	th := DarkTheme()
+	for _, tc := range testCases {
+		got, err := th.Markup(tc.in)
+		if err != nil {
+			t.Errorf("Fail to render markup %#v: %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Fail to render markup %#v.\nWant: %#v\nGot : %#v", tc.in, tc.want, got)
+		}
+	}
+
+	for _, in := range []string{"Hello[/]", "[red Hello", "[]Hello", "[blod]Hello[/]", "[error]Oops[/]"} {
+		if got, err := Markup(in); err == nil {
+			t.Errorf("Rendering markup %#v should fail but got %#v", in, got)
+		}
+	}
+}
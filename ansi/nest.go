@@ -0,0 +1,62 @@
+package ansi
+
+import (
+	"strings"
+)
+
+// Nest decorates the provided string using the Sequence so that the ANSI SGR
+// escape sequences found in s are applied on top of the Sequence instead of
+// replacing it: when s's escape sequences switch off their own Graphic
+// Renditions (like BoldOff, DefaultFG or even Reset), the Sequence's Graphic
+// Renditions are restored.
+//
+// For example, Sequence{"31"}.Nest(Green("Hello") + " world") renders
+// " world" in red whereas RedOn + Green("Hello") + " world" renders it using
+// terminal's default foreground color.
+func (seq Sequence) Nest(s string) string {
+	if s == "" || len(seq) == 0 {
+		return s
+	}
+
+	var out strings.Builder
+	var cur Style
+	var inner Sequence
+
+	_ = WalkString(s, func(n int, c rune, esc string) error {
+		switch {
+		case c > -1:
+			next := Combine(seq, inner).Style()
+			out.WriteString(Transition(cur, next))
+			cur = next
+			out.WriteRune(c)
+
+		case isSGR(esc):
+			inner.Combine(esc)
+			// a Reset within s only resets s's own Graphic Renditions.
+			if len(inner) > 0 && inner[0] == cReset {
+				inner = inner[1:]
+			}
+
+		default:
+			out.WriteString(esc)
+		}
+		return nil
+	})
+
+	out.WriteString(Transition(cur, Style{}))
+	return out.String()
+}
+
+// Nest decorates the provided string using the Style so that the ANSI SGR
+// escape sequences found in str are applied on top of the Style instead of
+// replacing it (see Sequence.Nest).
+func (s Style) Nest(str string) string {
+	return s.Sequence().Nest(str)
+}
+
+// Nest decorates the provided string with an ANSI SGR escape sequence (like
+// RedOn or BoldOn) so that the ANSI SGR escape sequences found in s are
+// applied on top of it instead of replacing it (see Sequence.Nest).
+func Nest(esc string, s string) string {
+	return ParseSGR(esc).Nest(s)
+}
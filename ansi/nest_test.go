@@ -0,0 +1,28 @@
+package ansi
+
+import (
+	"testing"
+)
+
+func TestNest(t *testing.T) {
+	testCases := []struct {
+		esc  string
+		in   string
+		want string
+	}{
+		{RedOn, "", ""},
+		{RedOn, "Hello", "\x1b[31mHello\x1b[0m"},
+		{RedOn, Green("Hello") + " world", "\x1b[32mHello\x1b[31m world\x1b[0m"},
+		{FaintOn, Bold("Hello") + " world", "\x1b[1;2mHello\x1b[0;2m world\x1b[0m"},
+		{BoldOn, "Hello " + Style{FG: ColorBlue}.Print("world"), "\x1b[1mHello \x1b[34mworld\x1b[0m"},
+		{BoldOn, Nest(RedOn, "Hello "+Green("beautiful")) + " world", "\x1b[1;31mHello \x1b[32mbeautiful\x1b[39m world\x1b[0m"},
+		{BoldOn, "Hello" + BoldOff + " world", "\x1b[1mHello\x1b[0m world"},
+		{RedOn, "Hello\x1b[2K world", "\x1b[31mHello\x1b[2K world\x1b[0m"},
+	}
+
+	for _, tc := range testCases {
+		if got := Nest(tc.esc, tc.in); got != tc.want {
+			t.Errorf("Fail to nest %#v in %#v.\nWant: %#v\nGot : %#v", tc.in, tc.esc, tc.want, got)
+		}
+	}
+}
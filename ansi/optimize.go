@@ -0,0 +1,147 @@
+package ansi
+
+import (
+	"strings"
+)
+
+// offCodes lists, for each group of attributes that share the same Code to be
+// switched off, the corresponding Code.
+var offCodes = []struct {
+	attr Attribute
+	off  Code
+}{
+	{AttrBold | AttrFaint, cNormal},
+	{AttrItalic, cItalicOff},
+	{AttrSlowBlink | AttrRapidBlink, cBlinkOff},
+	{AttrInverse, cInverseOff},
+	{AttrConceal, cReveal},
+	{AttrCrossedOut, cNotCrossedOut},
+	{AttrFramed | AttrEncircled, cNotFramed},
+	{AttrOverlined, cNotOverlined},
+}
+
+// Transition returns the shortest ANSI SGR escape sequence that moves the
+// terminal's Graphic Rendition from one Style to another. It returns an empty
+// string if both Styles render the same way.
+func Transition(from, to Style) string {
+	from, to = from.normalize(), to.normalize()
+	if from == to {
+		return ""
+	}
+
+	full := append(Sequence{cReset}, to.Sequence()...).String()
+	if diff := from.diff(to).String(); len(diff) <= len(full) {
+		return diff
+	}
+	return full
+}
+
+// diff returns the Sequence that moves the terminal's Graphic Rendition from
+// s to to, without resetting it.
+func (s Style) diff(to Style) (seq Sequence) {
+	on := to.Attr &^ s.Attr
+	for _, o := range offCodes {
+		if s.Attr&o.attr&^to.Attr != 0 {
+			seq = append(seq, o.off)
+			// off Code also disables attributes of the same group that
+			// are still expected.
+			on |= to.Attr & o.attr
+		}
+	}
+
+	for _, a := range attrCodes {
+		if on&a.attr != 0 {
+			seq = append(seq, a.on)
+		}
+	}
+
+	switch {
+	case s.Underline == to.Underline:
+	case to.Underline == NoUnderline:
+		seq = append(seq, cUnderlineOff)
+	default:
+		seq = append(seq, Style{Underline: to.Underline}.Sequence()...)
+	}
+
+	if s.FG != to.FG {
+		seq = append(seq, to.FG.orDefault().FG())
+	}
+	if s.BG != to.BG {
+		seq = append(seq, to.BG.orDefault().BG())
+	}
+	if s.UnderlineColor != to.UnderlineColor {
+		seq = append(seq, to.UnderlineColor.orDefault().underlineCode())
+	}
+
+	return
+}
+
+// normalize returns an equivalent Style where terminal's default colors are
+// considered as unset colors.
+func (s Style) normalize() Style {
+	if s.FG == ColorDefault {
+		s.FG = Color{}
+	}
+	if s.BG == ColorDefault {
+		s.BG = Color{}
+	}
+	if s.UnderlineColor == ColorDefault {
+		s.UnderlineColor = Color{}
+	}
+	return s
+}
+
+func (c Color) orDefault() Color {
+	if c.IsZero() {
+		return ColorDefault
+	}
+	return c
+}
+
+// Minify rewrites a string that contains ANSI escape sequences so that
+// redundant SGR escape sequences are collapsed: consecutive sequences are
+// merged, sequences that do not modify the Graphic Rendition are dropped and
+// remaining ones are replaced by the shortest transition between the
+// Graphic Renditions in place before and after them.
+//
+// Escape sequences that are not SGR sequences, or SGR sequences that are not
+// understood, are kept as is.
+func Minify(s string) string {
+	var out strings.Builder
+	var cur, next Style
+
+	flush := func() {
+		out.WriteString(Transition(cur, next))
+		cur = next
+	}
+
+	_ = WalkString(s, func(n int, c rune, esc string) error {
+		if c > -1 {
+			flush()
+			out.WriteRune(c)
+			return nil
+		}
+
+		seq := ParseSGR(esc)
+		candidate, understood := next, seq != nil
+		for _, code := range seq {
+			understood = candidate.apply(code) && understood
+		}
+
+		if understood {
+			next = candidate
+			return nil
+		}
+
+		flush()
+		out.WriteString(esc)
+		for _, code := range seq {
+			cur.apply(code)
+		}
+		next = cur
+		return nil
+	})
+
+	flush()
+	return out.String()
+}
@@ -0,0 +1,52 @@
+package ansi
+
+import (
+	"testing"
+)
+
+func TestTransition(t *testing.T) {
+	testCases := []struct {
+		from, to Style
+		want     string
+	}{
+		{Style{}, Style{}, ""},
+		{Style{FG: ColorRed}, Style{FG: ColorRed}, ""},
+		{Style{}, Style{FG: ColorDefault}, ""},
+		{Style{}, Style{FG: ColorRed, Attr: AttrBold}, "\x1b[1;31m"},
+		{Style{FG: ColorRed, Attr: AttrBold}, Style{FG: ColorGreen, Attr: AttrBold}, "\x1b[32m"},
+		{Style{FG: ColorRed, Attr: AttrBold}, Style{}, "\x1b[0m"},
+		{Style{Attr: AttrBold | AttrFaint}, Style{Attr: AttrFaint}, "\x1b[0;2m"},
+		{Style{Attr: AttrBold | AttrItalic, FG: ColorRed, BG: ColorBlue}, Style{Attr: AttrItalic, FG: ColorRed, BG: ColorBlue}, "\x1b[22m"},
+		{Style{Underline: CurlyUnderline, UnderlineColor: Indexed(1)}, Style{Underline: SingleUnderline}, "\x1b[0;4m"},
+		{Style{Underline: SingleUnderline, FG: ColorRed}, Style{FG: ColorRed}, "\x1b[24m"},
+		{Style{Attr: AttrBold | AttrFaint, FG: ColorRed}, Style{Attr: AttrFaint, FG: ColorRed}, "\x1b[22;2m"},
+	}
+
+	for _, tc := range testCases {
+		if got := Transition(tc.from, tc.to); got != tc.want {
+			t.Errorf("Fail to get transition from %v to %v.\nWant: %#v\nGot : %#v", tc.from.Sequence(), tc.to.Sequence(), tc.want, got)
+		}
+	}
+}
+
+func TestMinify(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{"Hello world!", "Hello world!"},
+		{"\x1b[1m\x1b[31mHello\x1b[0m", "\x1b[1;31mHello\x1b[0m"},
+		{"\x1b[31mHello\x1b[39m\x1b[31m world\x1b[39m", "\x1b[31mHello world\x1b[0m"},
+		{"\x1b[1m\x1b[32m42\x1b[39m\x1b[22m", "\x1b[1;32m42\x1b[0m"},
+		{"\x1b[34mHello\x1b[0m\x1b[34m world\x1b[0m!", "\x1b[34mHello world\x1b[0m!"},
+		{"\x1b[31m\x1b[0mHello", "Hello"},
+		{"\x1b[31mHello\x1b[2K world\x1b[0m", "\x1b[31mHello\x1b[2K world\x1b[0m"},
+		{"\x1b[31mHello\x1b[10m world\x1b[0m", "\x1b[31mHello\x1b[10m world\x1b[0m"},
+	}
+
+	for _, tc := range testCases {
+		if got := Minify(tc.in); got != tc.want {
+			t.Errorf("Fail to minify %#v.\nWant: %#v\nGot : %#v", tc.in, tc.want, got)
+		}
+	}
+}
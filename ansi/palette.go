@@ -0,0 +1,107 @@
+package ansi
+
+import (
+	"math"
+)
+
+// rgb represents a color in the sRGB color space.
+type rgb struct {
+	r, g, b uint8
+}
+
+var (
+	// palette16 contains the xterm's default values of the 16 standard
+	// colors (black, red, ..., white then their bright variants).
+	palette16 = [16]rgb{
+		{0x00, 0x00, 0x00}, {0xcd, 0x00, 0x00}, {0x00, 0xcd, 0x00}, {0xcd, 0xcd, 0x00},
+		{0x00, 0x00, 0xee}, {0xcd, 0x00, 0xcd}, {0x00, 0xcd, 0xcd}, {0xe5, 0xe5, 0xe5},
+		{0x7f, 0x7f, 0x7f}, {0xff, 0x00, 0x00}, {0x00, 0xff, 0x00}, {0xff, 0xff, 0x00},
+		{0x5c, 0x5c, 0xff}, {0xff, 0x00, 0xff}, {0x00, 0xff, 0xff}, {0xff, 0xff, 0xff},
+	}
+
+	// cubeLevels contains the value of each of the 6 steps of the 6x6x6
+	// color cube of the 256 colors palette.
+	cubeLevels = [6]uint8{0x00, 0x5f, 0x87, 0xaf, 0xd7, 0xff}
+)
+
+// palette256 returns the RGB value of a color of the 256 colors palette.
+func palette256(idx uint8) rgb {
+	switch {
+	case idx < 16:
+		return palette16[idx]
+
+	case idx < 232:
+		i := idx - 16
+		return rgb{cubeLevels[i/36], cubeLevels[(i/6)%6], cubeLevels[i%6]}
+
+	default:
+		v := 8 + 10*(idx-232)
+		return rgb{v, v, v}
+	}
+}
+
+// nearest16 returns the index of the color of the 16 colors palette that is
+// perceptually the closest to c.
+func nearest16(c rgb) uint8 {
+	return nearest(c, 0, 16)
+}
+
+// nearest256 returns the index of the color of the 256 colors palette that is
+// perceptually the closest to c.
+// The 16 first colors of the palette are ignored as their actual value
+// usually depends on the terminal's configuration.
+func nearest256(c rgb) uint8 {
+	return nearest(c, 16, 256)
+}
+
+func nearest(c rgb, from, to int) uint8 {
+	lab := c.lab()
+
+	best, bestDist := from, math.MaxFloat64
+	for i := from; i < to; i++ {
+		if d := lab.dist(palette256(uint8(i)).lab()); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+
+	return uint8(best)
+}
+
+// lab represents a color in the CIE L*a*b* color space, that is designed to
+// be perceptually uniform.
+type lab struct {
+	l, a, b float64
+}
+
+// dist returns the (squared) CIE76 distance between two colors.
+func (c lab) dist(o lab) float64 {
+	dl, da, db := c.l-o.l, c.a-o.a, c.b-o.b
+	return dl*dl + da*da + db*db
+}
+
+// lab converts an sRGB color to its CIE L*a*b* equivalent (D65 illuminant).
+func (c rgb) lab() lab {
+	linearize := func(v uint8) float64 {
+		f := float64(v) / 255
+		if f <= 0.04045 {
+			return f / 12.92
+		}
+		return math.Pow((f+0.055)/1.055, 2.4)
+	}
+
+	r, g, b := linearize(c.r), linearize(c.g), linearize(c.b)
+
+	x := (0.4124564*r + 0.3575761*g + 0.1804375*b) / 0.95047
+	y := 0.2126729*r + 0.7151522*g + 0.0721750*b
+	z := (0.0193339*r + 0.1191920*g + 0.9503041*b) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389.0 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27.0*t + 16) / 116
+	}
+
+	fx, fy, fz := f(x), f(y), f(z)
+	return lab{l: 116*fy - 16, a: 500 * (fx - fy), b: 200 * (fy - fz)}
+}
@@ -0,0 +1,247 @@
+package ansi
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// Profile represents the level of support of ANSI Graphic Rendition of a
+// terminal.
+type Profile int
+
+const (
+	// Plain profile does not support any ANSI Graphic Rendition. It is
+	// typically used when output is not a terminal.
+	Plain Profile = iota
+	// NoColor profile supports text attributes (like Bold or Underline) but
+	// no colors.
+	NoColor
+	// Color16 profile supports the 16 standard colors.
+	Color16
+	// Color256 profile supports the 8bit colors palette (256 colors).
+	Color256
+	// TrueColor profile supports the 24bit colors palette (R,G,B colors).
+	TrueColor
+)
+
+func (p Profile) String() string {
+	return [...]string{"Plain", "NoColor", "Color16", "Color256", "TrueColor"}[p]
+}
+
+// DetectProfile guesses the Profile supported by the terminal attached to f.
+//
+// DetectProfile relies on f being a terminal or not and on the usual
+// environment variables: NO_COLOR disables colors, CLICOLOR_FORCE forces
+// colors even if f is not a terminal, COLORTERM and TERM are used to
+// determine the supported colors palette.
+func DetectProfile(f *os.File) Profile {
+	return GuessProfile(term.IsTerminal(int(f.Fd())), os.Getenv)
+}
+
+// GuessProfile guesses the Profile supported by an output, knowing if it is a
+// terminal or not, from the environment variables returned by getenv (like
+// os.Getenv). It follows the same rules than DetectProfile.
+func GuessProfile(isTerminal bool, getenv func(string) string) Profile {
+	if force := getenv("CLICOLOR_FORCE"); !isTerminal && (force == "" || force == "0") {
+		return Plain
+	}
+
+	termName := strings.ToLower(getenv("TERM"))
+	if termName == "dumb" {
+		return Plain
+	}
+
+	if getenv("NO_COLOR") != "" {
+		return NoColor
+	}
+
+	switch colorTerm := strings.ToLower(getenv("COLORTERM")); {
+	case colorTerm == "truecolor" || colorTerm == "24bit":
+		return TrueColor
+	case strings.Contains(termName, "truecolor") || strings.Contains(termName, "direct"):
+		return TrueColor
+	case strings.Contains(termName, "256color"):
+		return Color256
+	}
+
+	return Color16
+}
+
+// Convert rewrites an ANSI SGR escape sequence so that it only uses Graphic
+// Renditions supported by the Profile. Colors that are not supported are
+// replaced by the perceptually closest supported one, or are removed if
+// Profile does not support colors at all.
+// Convert returns an empty string if no Graphic Rendition is left. OSC
+// sequences (like hyperlinks) are removed for the Plain Profile. Other escape
+// sequences that are not SGR sequences are returned unchanged.
+func (p Profile) Convert(esc string) string {
+	if p == Plain && strings.HasPrefix(esc, cOSC) {
+		return ""
+	}
+
+	if !isSGR(esc) {
+		return esc
+	}
+
+	if p == TrueColor {
+		return esc
+	}
+
+	var seq Sequence
+	for _, c := range ParseSGR(esc) {
+		if c = p.convert(c); c != "" {
+			seq = append(seq, c)
+		}
+	}
+
+	return seq.String()
+}
+
+// convert converts a Code so that it fits the Profile. It returns an empty
+// Code if Code is not supported at all.
+func (p Profile) convert(c Code) Code {
+	switch {
+	case p == Plain:
+		return ""
+
+	case !isFGColor(c) && !isBGColor(c) && !isUnderlineColor(c):
+		return c
+
+	case p == NoColor:
+		return ""
+	}
+
+	color, ok := colorFromCode(c)
+	if !ok {
+		return c
+	}
+
+	switch {
+	case color.kind == indexedColor && p >= Color256:
+		return c
+
+	case color.kind == indexedColor && color.idx < 16:
+		color = Color{kind: namedColor, idx: color.idx}
+
+	case color.kind == indexedColor || color.kind == rgbColor:
+		r, g, b := color.RGB()
+		if p == Color256 {
+			color = Indexed(nearest256(rgb{r, g, b}))
+		} else {
+			color = Color{kind: namedColor, idx: nearest16(rgb{r, g, b})}
+		}
+
+	default:
+		return c
+	}
+
+	switch {
+	case isBGColor(c):
+		return color.BG()
+	case isUnderlineColor(c):
+		return color.underlineCode()
+	}
+	return color.FG()
+}
+
+// Writer is an io.Writer that converts on the fly any ANSI SGR escape
+// sequence to fit the given Profile.
+//
+// Writer buffers escape sequences that are not terminated at the end of a
+// Write call so that they can be completed by next Write. You might need to
+// use Flush() to ensure that they are written to the output.
+type Writer struct {
+	out     io.Writer
+	profile Profile
+	pending []byte
+}
+
+// NewWriter creates a new Writer that converts ANSI SGR escape sequences to
+// fit the given Profile.
+func NewWriter(out io.Writer, p Profile) *Writer {
+	return &Writer{
+		out:     out,
+		profile: p,
+	}
+}
+
+// Write writes p to the Writer's output, after having converted any
+// encountered ANSI SGR escape sequence.
+func (w *Writer) Write(p []byte) (n int, err error) {
+	data := append(w.pending, p...)
+
+	cut := lastCompleteEsc(data)
+	w.pending = append([]byte{}, data[cut:]...)
+
+	var out bytes.Buffer
+	var last int
+	_ = Walk(data[:cut], func(n int, c rune, esc string) error {
+		if c == -1 {
+			out.WriteString(w.profile.Convert(esc))
+		} else {
+			out.Write(data[last:n])
+		}
+		last = n
+		return nil
+	})
+
+	if _, err = w.out.Write(out.Bytes()); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Flush writes any pending, not yet terminated, escape sequence to the
+// Writer's output after having converted it. It is dropped for the Plain
+// Profile.
+func (w *Writer) Flush() error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+
+	esc := w.profile.Convert(string(w.pending))
+	w.pending = w.pending[:0]
+	if w.profile == Plain || esc == "" {
+		return nil
+	}
+
+	_, err := io.WriteString(w.out, esc)
+	return err
+}
+
+// lastCompleteEsc returns the position in p after which starts an escape
+// sequence that is not terminated, or len(p) if there is none.
+func lastCompleteEsc(p []byte) int {
+	i := bytes.LastIndexByte(p, '\x1b')
+	if i < 0 {
+		return len(p)
+	}
+
+	// OSC sequences are terminated by BEL or by ST (ESC\\).
+	if bytes.HasPrefix(p[i:], []byte(cST)) {
+		return len(p)
+	}
+	if bytes.HasPrefix(p[i:], []byte(cOSC)) {
+		if bytes.IndexByte(p[i:], '\a') >= 0 {
+			return len(p)
+		}
+		return i
+	}
+
+	for j := i + 1; j < len(p); {
+		c, sz := utf8.DecodeRune(p[j:])
+		if unicode.IsLetter(c) || c == '~' {
+			return len(p)
+		}
+		j += sz
+	}
+
+	return i
+}
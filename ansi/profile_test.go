@@ -0,0 +1,113 @@
+package ansi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGuessProfile(t *testing.T) {
+	testCases := []struct {
+		isTerminal bool
+		env        map[string]string
+		want       Profile
+	}{
+		{false, map[string]string{"TERM": "xterm-256color"}, Plain},
+		{false, map[string]string{"TERM": "xterm-256color", "CLICOLOR_FORCE": "0"}, Plain},
+		{false, map[string]string{"TERM": "xterm-256color", "CLICOLOR_FORCE": "1"}, Color256},
+		{true, map[string]string{"TERM": "dumb"}, Plain},
+		{true, map[string]string{"TERM": "xterm-256color", "NO_COLOR": "1"}, NoColor},
+		{true, map[string]string{"TERM": "xterm"}, Color16},
+		{true, map[string]string{"TERM": "xterm-256color"}, Color256},
+		{true, map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, TrueColor},
+		{true, map[string]string{"TERM": "xterm-direct"}, TrueColor},
+	}
+
+	for _, tc := range testCases {
+		got := GuessProfile(tc.isTerminal, func(k string) string { return tc.env[k] })
+		if got != tc.want {
+			t.Errorf("Fail to detect profile for %v (terminal: %v).\nWant: %v\nGot : %v", tc.env, tc.isTerminal, tc.want, got)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	testCases := []struct {
+		in      string
+		profile Profile
+		want    string
+	}{
+		{cCSI + "1;38;2;255;135;0m", TrueColor, cCSI + "1;38;2;255;135;0m"},
+		{cCSI + "1;38;2;255;135;0m", Color256, cCSI + "1;38;5;208m"},
+		{cCSI + "1;38;2;255;135;0m", Color16, cCSI + "1;31m"},
+		{cCSI + "1;38;2;255;135;0m", NoColor, cCSI + "1m"},
+		{cCSI + "1;38;2;255;135;0m", Plain, ""},
+		{cCSI + "48;5;4m", Color16, cCSI + "44m"},
+		{cCSI + "48;5;12m", Color16, cCSI + "104m"},
+		{cCSI + "48;5;46m", Color16, cCSI + "102m"},
+		{cCSI + "38;5;232m", Color16, cCSI + "30m"},
+		{cCSI + "31m", NoColor, ""},
+		{cCSI + "0;31m", NoColor, cCSI + "0m"},
+		{cCSI + "A", Plain, cCSI + "A"},
+		{cOSC + "8;;https://example.com" + cST, Plain, ""},
+		{cOSC + "8;;\a", Plain, ""},
+		{cOSC + "8;;https://example.com" + cST, Color16, cOSC + "8;;https://example.com" + cST},
+	}
+
+	for _, tc := range testCases {
+		got := tc.profile.Convert(tc.in)
+		if got != tc.want {
+			t.Errorf("Fail to convert %#v to %v.\nWant: %#v\nGot : %#v", tc.in, tc.profile, tc.want, got)
+		}
+	}
+}
+
+func TestWriter(t *testing.T) {
+	testCases := []struct {
+		in      []string
+		profile Profile
+		want    string
+	}{
+		{
+			[]string{"Hello \x1b[38;2;0;0;255mworld\x1b[0m!"},
+			Color256,
+			"Hello \x1b[38;5;21mworld\x1b[0m!",
+		},
+		{
+			[]string{"Hello \x1b[38;2;0", ";0;255mworld\x1b[", "0m!"},
+			Color256,
+			"Hello \x1b[38;5;21mworld\x1b[0m!",
+		},
+		{
+			[]string{"Hello \x1b[1;31mworld\x1b[0m!"},
+			Plain,
+			"Hello world!",
+		},
+		{
+			[]string{"Hello \x1b[1;31mworld\x1b[0"},
+			Plain,
+			"Hello world",
+		},
+		{
+			[]string{"Hello \x1b[1;31mworld\x1b[0"},
+			Color16,
+			"Hello \x1b[1;31mworld\x1b[0",
+		},
+	}
+
+	for _, tc := range testCases {
+		got := new(strings.Builder)
+		w := NewWriter(got, tc.profile)
+		for _, s := range tc.in {
+			if _, err := w.Write([]byte(s)); err != nil {
+				t.Fatalf("Fail to write %#v: %v", s, err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("Fail to flush %#v: %v", tc.in, err)
+		}
+
+		if got.String() != tc.want {
+			t.Errorf("Fail to write %#v to %v.\nWant: %#v\nGot : %#v", tc.in, tc.profile, tc.want, got.String())
+		}
+	}
+}
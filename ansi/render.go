@@ -16,6 +16,11 @@ const (
 
 	DoublyUnderlined = "\x1b[21m"
 
+	DoubleUnderlineOn = "\x1b[4:2m"
+	CurlyUnderlineOn  = "\x1b[4:3m"
+	DottedUnderlineOn = "\x1b[4:4m"
+	DashedUnderlineOn = "\x1b[4:5m"
+
 	Normal        = "\x1b[22m"
 	BoldOff       = Normal
 	FaintOff      = Normal
@@ -53,6 +58,8 @@ const (
 	EncircledOff = FramedOff
 	OverlinedOff = "\x1b[55m"
 
+	DefaultUnderlineColor = "\x1b[59m"
+
 	BrightBlackOn   = "\x1b[90m"
 	BrightRedOn     = "\x1b[91m"
 	BrightGreenOn   = "\x1b[92m"
@@ -87,9 +94,31 @@ func FGColor24bit(r, g, b string) string {
 	return "\x1b[38;2;" + r + ";" + g + ";" + b + "m"
 }
 
-// BGColor24bit sets the foreground color in 24bit colors palette (R,G,B colors).
+// BGColor24bit sets the background color in 24bit colors palette (R,G,B colors).
 func BGColor24bit(r, g, b string) string {
-	return "\x1b[38;2;" + r + ";" + g + ";" + b + "m"
+	return "\x1b[48;2;" + r + ";" + g + ";" + b + "m"
+}
+
+// UnderlineColor8bit sets the underline color in 8bit colors palette (256
+// colors).
+func UnderlineColor8bit(color string) string {
+	return "\x1b[58;5;" + color + "m"
+}
+
+// UnderlineColor24bit sets the underline color in 24bit colors palette
+// (R,G,B colors).
+func UnderlineColor24bit(r, g, b string) string {
+	return "\x1b[58;2;" + r + ";" + g + ";" + b + "m"
+}
+
+// Hyperlink makes provided string a link to url using the OSC 8 escape
+// sequence. Terminals that do not support hyperlinks display s unchanged.
+func Hyperlink(url string, s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return cOSC + "8;;" + url + cST + s + cOSC + "8;;" + cST
 }
 
 // Bold sets provided string to Bold.
@@ -128,6 +157,42 @@ func Underline(s string) string {
 	return UnderlineOn + s + UnderlineOff
 }
 
+// DoubleUnderlined underlines provided string with a double line.
+func DoubleUnderlined(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return DoubleUnderlineOn + s + UnderlineOff
+}
+
+// CurlyUnderlined underlines provided string with a curly line.
+func CurlyUnderlined(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return CurlyUnderlineOn + s + UnderlineOff
+}
+
+// DottedUnderlined underlines provided string with a dotted line.
+func DottedUnderlined(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return DottedUnderlineOn + s + UnderlineOff
+}
+
+// DashedUnderlined underlines provided string with a dashed line.
+func DashedUnderlined(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return DashedUnderlineOn + s + UnderlineOff
+}
+
 // SlowBlink makes provided string to blink slowly.
 func SlowBlink(s string) string {
 	if s == "" {
@@ -492,49 +557,55 @@ func BrightWhiteBG(s string) string {
 // to use 'ansi' functions within templates.
 func FuncMap() map[string]interface{} {
 	return map[string]interface{}{
-		"Bold":            Bold,
-		"Faint":           Faint,
-		"Italic":          Italic,
-		"Underline":       Underline,
-		"SlowBlink":       SlowBlink,
-		"RapidBlink":      RapidBlink,
-		"Inverse":         Inverse,
-		"Conceal":         Conceal,
-		"CrossedOut":      CrossedOut,
-		"Black":           Black,
-		"Red":             Red,
-		"Green":           Green,
-		"Yellow":          Yellow,
-		"Blue":            Blue,
-		"Magenta":         Magenta,
-		"Cyan":            Cyan,
-		"White":           White,
-		"BlackBG":         BlackBG,
-		"RedBG":           RedBG,
-		"GreenBG":         GreenBG,
-		"YellowBG":        YellowBG,
-		"BlueBG":          BlueBG,
-		"MagentaBG":       MagentaBG,
-		"CyanBG":          CyanBG,
-		"WhiteBG":         WhiteBG,
-		"Framed":          Framed,
-		"Encircled":       Encircled,
-		"Overlined":       Overlined,
-		"BrightBlack":     BrightBlack,
-		"BrightRed":       BrightRed,
-		"BrightGreen":     BrightGreen,
-		"BrightYellow":    BrightYellow,
-		"BrightBlue":      BrightBlue,
-		"BrightMagenta":   BrightMagenta,
-		"BrightCyan":      BrightCyan,
-		"BrightWhite":     BrightWhite,
-		"BrightBlackBG":   BrightBlackBG,
-		"BrightRedBG":     BrightRedBG,
-		"BrightGreenBG":   BrightGreenBG,
-		"BrightYellowBG":  BrightYellowBG,
-		"BrightBlueBG":    BrightBlueBG,
-		"BrightMagentaBG": BrightMagentaBG,
-		"BrightCyanBG":    BrightCyanBG,
-		"BrightWhiteBG":   BrightWhiteBG,
+		"Bold":             Bold,
+		"Faint":            Faint,
+		"Italic":           Italic,
+		"Underline":        Underline,
+		"DoubleUnderlined": DoubleUnderlined,
+		"CurlyUnderlined":  CurlyUnderlined,
+		"DottedUnderlined": DottedUnderlined,
+		"DashedUnderlined": DashedUnderlined,
+		"SlowBlink":        SlowBlink,
+		"RapidBlink":       RapidBlink,
+		"Inverse":          Inverse,
+		"Conceal":          Conceal,
+		"CrossedOut":       CrossedOut,
+		"Hyperlink":        Hyperlink,
+		"Black":            Black,
+		"Red":              Red,
+		"Green":            Green,
+		"Yellow":           Yellow,
+		"Blue":             Blue,
+		"Magenta":          Magenta,
+		"Cyan":             Cyan,
+		"White":            White,
+		"BlackBG":          BlackBG,
+		"RedBG":            RedBG,
+		"GreenBG":          GreenBG,
+		"YellowBG":         YellowBG,
+		"BlueBG":           BlueBG,
+		"MagentaBG":        MagentaBG,
+		"CyanBG":           CyanBG,
+		"WhiteBG":          WhiteBG,
+		"Framed":           Framed,
+		"Encircled":        Encircled,
+		"Overlined":        Overlined,
+		"BrightBlack":      BrightBlack,
+		"BrightRed":        BrightRed,
+		"BrightGreen":      BrightGreen,
+		"BrightYellow":     BrightYellow,
+		"BrightBlue":       BrightBlue,
+		"BrightMagenta":    BrightMagenta,
+		"BrightCyan":       BrightCyan,
+		"BrightWhite":      BrightWhite,
+		"BrightBlackBG":    BrightBlackBG,
+		"BrightRedBG":      BrightRedBG,
+		"BrightGreenBG":    BrightGreenBG,
+		"BrightYellowBG":   BrightYellowBG,
+		"BrightBlueBG":     BrightBlueBG,
+		"BrightMagentaBG":  BrightMagentaBG,
+		"BrightCyanBG":     BrightCyanBG,
+		"BrightWhiteBG":    BrightWhiteBG,
+		"Markup":           Markup,
 	}
 }
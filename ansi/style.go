@@ -0,0 +1,220 @@
+package ansi
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Attribute represents a set of text attributes.
+type Attribute uint16
+
+// Text attributes that can be combined together to form a Style.
+const (
+	AttrBold Attribute = 1 << iota
+	AttrFaint
+	AttrItalic
+	AttrSlowBlink
+	AttrRapidBlink
+	AttrInverse
+	AttrConceal
+	AttrCrossedOut
+	AttrFramed
+	AttrEncircled
+	AttrOverlined
+)
+
+var attrCodes = []struct {
+	attr Attribute
+	on   Code
+}{
+	{AttrBold, cBold},
+	{AttrFaint, cFaint},
+	{AttrItalic, cItalic},
+	{AttrSlowBlink, cSlowBlink},
+	{AttrRapidBlink, cRapidBlink},
+	{AttrInverse, cInverse},
+	{AttrConceal, cConceal},
+	{AttrCrossedOut, cCrossedOut},
+	{AttrFramed, cFramed},
+	{AttrEncircled, cEncircled},
+	{AttrOverlined, cOverlined},
+}
+
+// UnderlineStyle represents the way a text is underlined.
+type UnderlineStyle uint8
+
+// Supported underline styles.
+const (
+	NoUnderline UnderlineStyle = iota
+	SingleUnderline
+	DoubleUnderline
+	CurlyUnderline
+	DottedUnderline
+	DashedUnderline
+)
+
+// Style represents a set of Graphic Renditions that can be applied to a
+// text.
+//
+// Style is a comparable value type. Its zero value represents a Style that
+// does not modify the terminal's current Graphic Rendition.
+type Style struct {
+	// FG is the foreground color.
+	FG Color
+	// BG is the background color.
+	BG Color
+	// Attr is the set of text attributes.
+	Attr Attribute
+	// Underline is the underline style.
+	Underline UnderlineStyle
+	// UnderlineColor is the underline color.
+	UnderlineColor Color
+}
+
+// Merge combines two Styles. Attributes of both Styles are kept, colors and
+// underline style of o replace s ones if they are set.
+func (s Style) Merge(o Style) Style {
+	s.Attr |= o.Attr
+
+	if !o.FG.IsZero() {
+		s.FG = o.FG
+	}
+	if !o.BG.IsZero() {
+		s.BG = o.BG
+	}
+	if o.Underline != NoUnderline {
+		s.Underline = o.Underline
+	}
+	if !o.UnderlineColor.IsZero() {
+		s.UnderlineColor = o.UnderlineColor
+	}
+
+	return s
+}
+
+// Sequence returns the Style's corresponding Sequence.
+func (s Style) Sequence() (seq Sequence) {
+	for _, a := range attrCodes {
+		if s.Attr&a.attr != 0 {
+			seq = append(seq, a.on)
+		}
+	}
+
+	switch s.Underline {
+	case NoUnderline:
+	case SingleUnderline:
+		seq = append(seq, cUnderline)
+	default:
+		seq = append(seq, cUnderline+":"+strconv.Itoa(int(s.Underline)))
+	}
+
+	if c := s.FG.FG(); c != "" {
+		seq = append(seq, c)
+	}
+	if c := s.BG.BG(); c != "" {
+		seq = append(seq, c)
+	}
+	if c := s.UnderlineColor.underlineCode(); c != "" {
+		seq = append(seq, c)
+	}
+
+	return
+}
+
+// String returns the Style's ANSI escape sequence.
+func (s Style) String() string {
+	return s.Sequence().String()
+}
+
+// Print decorates the provided string using the Style.
+func (s Style) Print(str string) string {
+	return s.Sequence().Print(str)
+}
+
+// Style returns the Style that corresponds to the Graphic Renditions
+// described by the Sequence.
+func (seq Sequence) Style() (s Style) {
+	for _, c := range seq {
+		s.apply(c)
+	}
+	return
+}
+
+// apply updates the Style with the effect of the given Code. It returns false
+// if Code is not understood.
+func (s *Style) apply(c Code) bool {
+	switch c {
+	case cReset:
+		*s = Style{}
+	case cUnderline:
+		s.Underline = SingleUnderline
+	case cUnderlineOff:
+		s.Underline = NoUnderline
+	case cBoldOff:
+		s.Attr &^= AttrBold
+	case cNormal:
+		s.Attr &^= AttrBold | AttrFaint
+	case cItalicOff:
+		s.Attr &^= AttrItalic
+	case cBlinkOff:
+		s.Attr &^= AttrSlowBlink | AttrRapidBlink
+	case cInverseOff:
+		s.Attr &^= AttrInverse
+	case cReveal:
+		s.Attr &^= AttrConceal
+	case cNotCrossedOut:
+		s.Attr &^= AttrCrossedOut
+	case cNotFramed:
+		s.Attr &^= AttrFramed | AttrEncircled
+	case cNotOverlined:
+		s.Attr &^= AttrOverlined
+	default:
+		return s.applyOn(c)
+	}
+
+	return true
+}
+
+func (s *Style) applyOn(c Code) bool {
+	for _, a := range attrCodes {
+		if c == a.on {
+			s.Attr |= a.attr
+			return true
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(c, cUnderline+":"):
+		n, err := strconv.Atoi(c[2:])
+		if err != nil || n < 0 || n > int(DashedUnderline) {
+			return false
+		}
+		s.Underline = UnderlineStyle(n)
+
+	case isFGColor(c):
+		color, ok := colorFromCode(c)
+		if !ok {
+			return false
+		}
+		s.FG = color
+
+	case isBGColor(c):
+		color, ok := colorFromCode(c)
+		if !ok {
+			return false
+		}
+		s.BG = color
+
+	case isUnderlineColor(c):
+		color, ok := colorFromCode(c)
+		if !ok {
+			return false
+		}
+		s.UnderlineColor = color
+
+	default:
+		return false
+	}
+
+	return true
+}
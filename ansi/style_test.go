@@ -0,0 +1,58 @@
+package ansi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStyleSequence(t *testing.T) {
+	testCases := []struct {
+		in   Style
+		want Sequence
+	}{
+		{Style{}, nil},
+		{Style{FG: ColorRed, Attr: AttrBold}, Sequence{cBold, cRed}},
+		{Style{FG: RGB(255, 136, 0), BG: Indexed(236), Attr: AttrItalic | AttrCrossedOut}, Sequence{cItalic, cCrossedOut, "38;2;255;136;0", "48;5;236"}},
+		{Style{Underline: SingleUnderline}, Sequence{cUnderline}},
+		{Style{Underline: CurlyUnderline, UnderlineColor: Indexed(160)}, Sequence{"4:3", "58;5;160"}},
+	}
+
+	for _, tc := range testCases {
+		got := tc.in.Sequence()
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Fail to get Sequence of %#v.\nWant: %#v\nGot : %#v", tc.in, tc.want, got)
+		}
+
+		if rt := got.Style(); rt != tc.in {
+			t.Errorf("Fail to round-trip Style %#v.\nGot : %#v", tc.in, rt)
+		}
+	}
+}
+
+func TestSequenceStyle(t *testing.T) {
+	testCases := []struct {
+		in   Sequence
+		want Style
+	}{
+		{Sequence{cBold, cRed, cNormal}, Style{FG: ColorRed}},
+		{Sequence{cBold, cRed, cReset, cGreenBG}, Style{BG: ColorGreen}},
+		{Sequence{cBrightBlue, "38;5;12"}, Style{FG: Indexed(12)}},
+		{Sequence{cUnderline, cUnderlineOff, cDefaultFG}, Style{FG: ColorDefault}},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.in.Style(); got != tc.want {
+			t.Errorf("Fail to get Style of %#v.\nWant: %#v\nGot : %#v", tc.in, tc.want, got)
+		}
+	}
+}
+
+func TestStyleMerge(t *testing.T) {
+	a := Style{FG: ColorRed, Attr: AttrBold}
+	b := Style{BG: ColorBlue, Attr: AttrItalic, FG: Indexed(208)}
+
+	want := Style{FG: Indexed(208), BG: ColorBlue, Attr: AttrBold | AttrItalic}
+	if got := a.Merge(b); got != want {
+		t.Errorf("Fail to merge %#v and %#v.\nWant: %#v\nGot : %#v", a, b, want, got)
+	}
+}
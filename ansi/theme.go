@@ -0,0 +1,208 @@
+package ansi
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Theme maps semantic names (like "error", "warning" or "diff.inserted") to
+// Styles.
+//
+// By convention, names are lower-case words separated by dots, the first word
+// identifying the component that uses the Style.
+type Theme map[string]Style
+
+// DarkTheme returns a Theme suitable for terminals with a dark background.
+func DarkTheme() Theme {
+	return Theme{
+		"error":    {FG: ColorBrightRed, Attr: AttrBold},
+		"warning":  {FG: ColorBrightYellow},
+		"info":     {FG: ColorBrightCyan},
+		"success":  {FG: ColorBrightGreen},
+		"muted":    {FG: ColorBrightBlack},
+		"emphasis": {Attr: AttrBold},
+
+		"diff.deleted":          {FG: ColorBrightBlue},
+		"diff.inserted":         {FG: ColorBrightRed},
+		"diff.different":        {FG: ColorBrightRed},
+		"diff.missing":          {Attr: AttrCrossedOut},
+		"diff.deleted.marker":   {FG: ColorWhite, BG: ColorBlue},
+		"diff.inserted.marker":  {FG: ColorWhite, BG: ColorRed},
+		"diff.different.marker": {FG: ColorWhite, BG: ColorRed},
+
+		"table.header": {Attr: AttrBold},
+		"table.footer": {Attr: AttrBold},
+		"table.grid":   {FG: ColorBrightBlack},
+	}
+}
+
+// LightTheme returns a Theme suitable for terminals with a light background.
+func LightTheme() Theme {
+	return Theme{
+		"error":    {FG: ColorRed, Attr: AttrBold},
+		"warning":  {FG: ColorYellow},
+		"info":     {FG: ColorBlue},
+		"success":  {FG: ColorGreen},
+		"muted":    {FG: ColorBrightBlack},
+		"emphasis": {Attr: AttrBold},
+
+		"diff.deleted":          {FG: ColorBlue},
+		"diff.inserted":         {FG: ColorRed},
+		"diff.different":        {FG: ColorRed},
+		"diff.missing":          {Attr: AttrCrossedOut},
+		"diff.deleted.marker":   {FG: ColorBrightWhite, BG: ColorBlue},
+		"diff.inserted.marker":  {FG: ColorBrightWhite, BG: ColorRed},
+		"diff.different.marker": {FG: ColorBrightWhite, BG: ColorRed},
+
+		"table.header": {Attr: AttrBold},
+		"table.footer": {Attr: AttrBold},
+		"table.grid":   {FG: ColorBlack},
+	}
+}
+
+// Style returns the Style corresponding to the given name. It returns the zero
+// Style if name is unknown.
+func (t Theme) Style(name string) Style {
+	return t[name]
+}
+
+// Print decorates the provided string using the Style corresponding to the
+// given name. If name is unknown, s is returned unchanged.
+func (t Theme) Print(name string, s string) string {
+	return t[name].Print(s)
+}
+
+// Merge returns a new Theme made of t's Styles completed or replaced by o's
+// ones.
+func (t Theme) Merge(o Theme) Theme {
+	m := make(Theme, len(t)+len(o))
+	for name, style := range t {
+		m[name] = style
+	}
+	for name, style := range o {
+		m[name] = style
+	}
+	return m
+}
+
+// LoadTheme reads a Theme from an INI-like configuration.
+//
+// Each line of the configuration is of the form 'name = style' where style
+// follows ParseStyle's syntax. Lines starting with '#' or ';' are comments.
+// A '[section]' line prefixes the names of the following lines with
+// 'section.', for example:
+//
+//	error = bold red
+//
+//	[diff]
+//	inserted = red
+//	inserted.marker = white on red
+func LoadTheme(r io.Reader) (Theme, error) {
+	t := Theme{}
+
+	var section string
+	scanner := bufio.NewScanner(r)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || line[0] == '#' || line[0] == ';':
+			continue
+
+		case line[0] == '[' && line[len(line)-1] == ']':
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("line %d: missing '=' in '%s'", lineno, line)
+		}
+
+		name := strings.ToLower(strings.TrimSpace(kv[0]))
+		if section != "" {
+			name = strings.ToLower(section) + "." + name
+		}
+
+		style, err := ParseStyle(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineno, err)
+		}
+
+		t[name] = style
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+var attrNames = map[string]Style{
+	"bold":             {Attr: AttrBold},
+	"faint":            {Attr: AttrFaint},
+	"dim":              {Attr: AttrFaint},
+	"italic":           {Attr: AttrItalic},
+	"underline":        {Underline: SingleUnderline},
+	"double-underline": {Underline: DoubleUnderline},
+	"curly-underline":  {Underline: CurlyUnderline},
+	"dotted-underline": {Underline: DottedUnderline},
+	"dashed-underline": {Underline: DashedUnderline},
+	"blink":            {Attr: AttrSlowBlink},
+	"rapid-blink":      {Attr: AttrRapidBlink},
+	"inverse":          {Attr: AttrInverse},
+	"reverse":          {Attr: AttrInverse},
+	"conceal":          {Attr: AttrConceal},
+	"crossed-out":      {Attr: AttrCrossedOut},
+	"strike":           {Attr: AttrCrossedOut},
+	"framed":           {Attr: AttrFramed},
+	"encircled":        {Attr: AttrEncircled},
+	"overlined":        {Attr: AttrOverlined},
+}
+
+// ParseStyle parses a Style from a space separated list of words, like
+// "bold red on #303030".
+//
+// Recognized words are text attributes ("bold", "faint", "italic",
+// "underline", "curly-underline", "inverse", "strike"...) and colors (as
+// understood by ParseColor). A color alone sets the foreground color, a color
+// preceded by "on" sets the background color and a color preceded by "under"
+// sets the underline color.
+func ParseStyle(spec string) (Style, error) {
+	var s Style
+
+	words := strings.Fields(strings.ToLower(spec))
+	for i := 0; i < len(words); i++ {
+		if a, ok := attrNames[words[i]]; ok {
+			s = s.Merge(a)
+			continue
+		}
+
+		var target *Color
+		switch words[i] {
+		case "on":
+			target = &s.BG
+			i++
+		case "under":
+			target = &s.UnderlineColor
+			i++
+		default:
+			target = &s.FG
+		}
+
+		if i >= len(words) {
+			return Style{}, fmt.Errorf("missing color at the end of style '%s'", spec)
+		}
+
+		c, err := ParseColor(words[i])
+		if err != nil {
+			return Style{}, fmt.Errorf("invalid style '%s': %v", spec, err)
+		}
+		*target = c
+	}
+
+	return s, nil
+}
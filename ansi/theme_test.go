@@ -0,0 +1,77 @@
+package ansi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseStyle(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want Style
+	}{
+		{"", Style{}},
+		{"bold red", Style{FG: ColorRed, Attr: AttrBold}},
+		{"Bold  Italic #ff8800 on 236", Style{FG: RGB(0xff, 0x88, 0x00), BG: Indexed(236), Attr: AttrBold | AttrItalic}},
+		{"on blue white", Style{FG: ColorWhite, BG: ColorBlue}},
+		{"curly-underline under bright-red", Style{Underline: CurlyUnderline, UnderlineColor: ColorBrightRed}},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseStyle(tc.in)
+		if err != nil {
+			t.Errorf("Fail to parse style %#v: %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Fail to parse style %#v.\nWant: %#v\nGot : %#v", tc.in, tc.want, got)
+		}
+	}
+
+	for _, in := range []string{"bold on", "blod", "red on #zzz"} {
+		if got, err := ParseStyle(in); err == nil {
+			t.Errorf("Parsing style %#v should fail but got %#v", in, got)
+		}
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	cfg := `
+# my theme
+error = bold red
+
+[Diff]
+inserted = green
+inserted.marker = white on green
+`
+	want := Theme{
+		"error":                {FG: ColorRed, Attr: AttrBold},
+		"diff.inserted":        {FG: ColorGreen},
+		"diff.inserted.marker": {FG: ColorWhite, BG: ColorGreen},
+	}
+
+	got, err := LoadTheme(strings.NewReader(cfg))
+	if err != nil {
+		t.Fatalf("Fail to load theme: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Fail to load theme.\nWant: %#v\nGot : %#v", want, got)
+	}
+
+	if _, err := LoadTheme(strings.NewReader("error = bold\nwarning yellow\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Loading invalid theme should fail at line 2, got: %v", err)
+	}
+}
+
+func TestThemePrint(t *testing.T) {
+	th := DarkTheme().Merge(Theme{"error": {FG: ColorRed}})
+
+	if got, want := th.Print("error", "oops"), "\x1b[31moops\x1b[0m"; got != want {
+		t.Errorf("Fail to print with theme.\nWant: %#v\nGot : %#v", want, got)
+	}
+
+	if got, want := th.Print("unknown", "oops"), "oops"; got != want {
+		t.Errorf("Fail to print with theme.\nWant: %#v\nGot : %#v", want, got)
+	}
+}
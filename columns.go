@@ -0,0 +1,117 @@
+package text
+
+import (
+	"strings"
+
+	"github.com/pirmd/text/visual"
+)
+
+// columnsGap separates two columns of items.
+const columnsGap = "  "
+
+// Columns organizes a list of items in as many columns as possible so that
+// each line's "visual" length is lower or equal to the provided limit, the
+// way `ls` does. Items are sorted down the columns (top-to-bottom then
+// left-to-right).
+//
+// If items do not fit on several columns, they are displayed one per line.
+func Columns(items []string, sz int) string {
+	return columns(items, sz, false)
+}
+
+// ColumnsAcross organizes a list of items in as many columns as possible so
+// that each line's "visual" length is lower or equal to the provided limit,
+// the way `ls -x` does. Items are sorted across the columns (left-to-right
+// then top-to-bottom).
+//
+// If items do not fit on several columns, they are displayed one per line.
+func ColumnsAcross(items []string, sz int) string {
+	return columns(items, sz, true)
+}
+
+func columns(items []string, sz int, across bool) string {
+	if len(items) == 0 {
+		return ""
+	}
+
+	widths := make([]int, len(items))
+	for i, item := range items {
+		widths[i] = visual.Stringwidth(item)
+	}
+
+	nrows, colWidth := len(items), []int{maxOf(widths)}
+	for ncols := len(items); ncols > 1; ncols-- {
+		r := (len(items) + ncols - 1) / ncols
+		if !across {
+			// some columns might stay empty when filling top-to-bottom.
+			if (len(items)+r-1)/r != ncols {
+				continue
+			}
+		}
+
+		if w := columnsWidth(widths, r, ncols, across); sumOf(w)+len(columnsGap)*(ncols-1) <= sz {
+			nrows, colWidth = r, w
+			break
+		}
+	}
+
+	lines := make([]string, nrows)
+	for row := range lines {
+		var line strings.Builder
+		for col, w := range colWidth {
+			i := columnsIndex(row, col, nrows, len(colWidth), across)
+			if i >= len(items) {
+				break
+			}
+
+			if col > 0 {
+				line.WriteString(columnsGap)
+			}
+			line.WriteString(items[i])
+			line.WriteString(strings.Repeat(" ", w-widths[i]))
+		}
+		lines[row] = string(visual.TrimTrailingSpace([]byte(line.String())))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// columnsWidth returns the width of each column when displaying items of the
+// given widths in nrows rows and ncols columns.
+func columnsWidth(widths []int, nrows, ncols int, across bool) []int {
+	colWidth := make([]int, ncols)
+	for row := 0; row < nrows; row++ {
+		for col := 0; col < ncols; col++ {
+			i := columnsIndex(row, col, nrows, ncols, across)
+			if i < len(widths) && widths[i] > colWidth[col] {
+				colWidth[col] = widths[i]
+			}
+		}
+	}
+	return colWidth
+}
+
+// columnsIndex returns the index of the item displayed at the given row and
+// column.
+func columnsIndex(row, col, nrows, ncols int, across bool) int {
+	if across {
+		return row*ncols + col
+	}
+	return col*nrows + row
+}
+
+func maxOf(values []int) (m int) {
+	for _, v := range values {
+		if v > m {
+			m = v
+		}
+	}
+	return
+}
+
+func sumOf(values []int) (s int) {
+	for _, v := range values {
+		s += v
+	}
+	return
+}
@@ -0,0 +1,49 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestColumns(t *testing.T) {
+	items := []string{"bin", "boot", "dev", "etc", "home", "lib", "lost+found", "mnt", "opt", "proc"}
+
+	testCases := []struct {
+		in     []string
+		sz     int
+		want   string
+		across string
+	}{
+		{nil, 20, "", ""},
+		{[]string{"one"}, 20, "one", "one"},
+		{[]string{"one", "two", "three"}, 80, "one  two  three", "one  two  three"},
+		{
+			items, 40,
+			"bin   dev  home  lost+found  opt\nboot  etc  lib   mnt         proc",
+			"bin         boot  dev  etc   home  lib\nlost+found  mnt   opt  proc",
+		},
+		{
+			items, 30,
+			"bin   etc   lost+found  proc\nboot  home  mnt\ndev   lib   opt",
+			"bin   boot  dev         etc\nhome  lib   lost+found  mnt\nopt   proc",
+		},
+		{
+			items[:4], 3,
+			"bin\nboot\ndev\netc",
+			"bin\nboot\ndev\netc",
+		},
+		{
+			[]string{"\x1b[34m日本\x1b[0m", "a", "ab", "abc"}, 10,
+			"\x1b[34m日本\x1b[0m  ab\na     abc",
+			"\x1b[34m日本\x1b[0m  a\nab    abc",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := Columns(tc.in, tc.sz); got != tc.want {
+			t.Errorf("Fail to organize %q in columns of %d.\nWant:\n%s\nGot :\n%s", tc.in, tc.sz, tc.want, got)
+		}
+		if got := ColumnsAcross(tc.in, tc.sz); got != tc.across {
+			t.Errorf("Fail to organize %q across columns of %d.\nWant:\n%s\nGot :\n%s", tc.in, tc.sz, tc.across, got)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package text
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pirmd/text/visual"
+)
+
+const (
+	// DefaultMaxTagWidth is the default maximum width of a DefList's tags
+	// column.
+	DefaultMaxTagWidth = 24
+
+	// defListGap separates a DefList's tags from their description.
+	defListGap = "  "
+)
+
+// DefList represents a list of tags and their description, like a definition
+// list or a command's options list (as displayed by flag.PrintDefaults or by
+// most --help outputs).
+//
+// Tags are displayed in a column whose width is the one of the largest tag,
+// descriptions being wrapped next to them. Tags that are larger than the
+// maximum tag's width are displayed on their own line, their description
+// starting on the following line.
+type DefList struct {
+	tags         []string
+	descriptions []string
+
+	// maxWidth is the maximum width of the DefList.
+	maxWidth int
+	// maxTagWidth is the maximum width of the tags' column.
+	maxTagWidth int
+	// indent is the prefix added at the beginning of each line.
+	indent string
+}
+
+// NewDefList returns a new empty DefList whose maximum width is set-up to
+// the terminal width or to table.DefaultMaxWidth.
+func NewDefList() *DefList {
+	return &DefList{
+		maxWidth:    TermWidth(),
+		maxTagWidth: DefaultMaxTagWidth,
+	}
+}
+
+// SetMaxWidth sets the DefList's maximum width.
+func (l *DefList) SetMaxWidth(w int) *DefList {
+	l.maxWidth = w
+	return l
+}
+
+// SetMaxTagWidth sets the maximum width of the DefList's tags column.
+func (l *DefList) SetMaxTagWidth(w int) *DefList {
+	l.maxTagWidth = w
+	return l
+}
+
+// SetIndent sets a prefix that is added at the beginning of each line of
+// the DefList.
+func (l *DefList) SetIndent(prefix string) *DefList {
+	l.indent = prefix
+	return l
+}
+
+// Add adds a new tag and its description to the DefList.
+func (l *DefList) Add(tag, description string) *DefList {
+	l.tags = append(l.tags, tag)
+	l.descriptions = append(l.descriptions, description)
+	return l
+}
+
+// String returns the DefList's formatted string.
+func (l *DefList) String() string {
+	var out strings.Builder
+	_, _ = l.WriteTo(&out)
+	return out.String()
+}
+
+// WriteTo writes the formatted DefList to w.
+func (l *DefList) WriteTo(w io.Writer) (int64, error) {
+	col := l.tagColWidth()
+	prefix := l.indent + strings.Repeat(" ", col+visual.Stringwidth(defListGap))
+
+	var n int64
+	for i, tag := range l.tags {
+		var item string
+
+		switch desc := strings.TrimSpace(l.descriptions[i]); {
+		case desc == "":
+			item = l.indent + tag
+
+		case visual.Stringwidth(tag) > col:
+			item = l.indent + tag + "\n" + Tab(desc, prefix, prefix, l.maxWidth)
+
+		default:
+			tag = l.indent + string(visual.PadRight([]byte(tag), col)) + defListGap
+			item = Tab(desc, tag, prefix, l.maxWidth)
+		}
+
+		nn, err := io.WriteString(w, item+"\n")
+		n += int64(nn)
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return n, nil
+}
+
+// tagColWidth returns the width of the tags' column, that is the width of
+// the largest tag that does not exceed the maximum tag's width.
+func (l *DefList) tagColWidth() (col int) {
+	for _, tag := range l.tags {
+		if w := visual.Stringwidth(tag); w > col && w <= l.maxTagWidth {
+			col = w
+		}
+	}
+	return
+}
@@ -0,0 +1,66 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestDefList(t *testing.T) {
+	testCases := []struct {
+		in          [][2]string
+		maxTagWidth int
+		indent      string
+		want        string
+	}{
+		{
+			in: [][2]string{
+				{"-v", "Print more information."},
+				{"--output FILE", "Write the result to FILE instead of the standard output."},
+				{"-h"},
+			},
+			maxTagWidth: 16,
+			indent:      "  ",
+			want: "  -v             Print more information.\n" +
+				"  --output FILE  Write the result to FILE\n" +
+				"                 instead of the standard\n" +
+				"                 output.\n" +
+				"  -h\n",
+		},
+		{
+			in: [][2]string{
+				{"-v", "Print more information."},
+				{"--output FILE", "Write the result to FILE instead of the standard output."},
+			},
+			maxTagWidth: 8,
+			want: "-v  Print more information.\n" +
+				"--output FILE\n" +
+				"    Write the result to FILE instead of the\n" +
+				"    standard output.\n",
+		},
+		{
+			in: [][2]string{
+				{"\x1b[1m日本\x1b[0m", "Japan, in Japanese."},
+				{"\x1b[1mFrance\x1b[0m", "France, in French."},
+			},
+			maxTagWidth: 10,
+			want: "\x1b[1m日本\x1b[0m    Japan, in Japanese.\n" +
+				"\x1b[1mFrance\x1b[0m  France, in French.\n",
+		},
+		{
+			in:          [][2]string{{"all-too-long", "Everything is\ndisplayed below."}},
+			maxTagWidth: 4,
+			indent:      "  ",
+			want:        "  all-too-long\n    Everything is\n    displayed below.\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		l := NewDefList().SetMaxWidth(44).SetMaxTagWidth(tc.maxTagWidth).SetIndent(tc.indent)
+		for _, item := range tc.in {
+			l.Add(item[0], item[1])
+		}
+
+		if got := l.String(); got != tc.want {
+			t.Errorf("Fail to format definition list %q.\nWant:\n%s\nGot :\n%s", tc.in, tc.want, got)
+		}
+	}
+}
@@ -5,12 +5,14 @@ import (
 	"unicode"
 
 	"github.com/pirmd/text/ansi"
+	"github.com/pirmd/text/visual"
 )
 
 const (
 	tabAlias          = "\u21e5\u21e5\u21e5\u21e5"
 	spaceAlias        = '\u00B7'
 	nonPrintableAlias = '\ufffd'
+	softTabWidth      = 4
 )
 
 var (
@@ -60,9 +62,9 @@ var (
 		},
 	}
 
-	// WithSoftTabs replaces any tabs ('\t') by four consecutives spaces so
-	// that it does not voids any further text formatting (like showing diff in
-	// columns)
+	// WithSoftTabs replaces any tabs ('\t') by spaces up to the next tab
+	// stop (every four columns) so that it does not voids any further text
+	// formatting (like showing diff in columns)
 	WithSoftTabs = Highlighter{
 		Same:      func(dL, dR, dT string) (string, string, string) { return expandTabs(dL), expandTabs(dR), dT },
 		Deleted:   func(dL, dR, dT string) (string, string, string) { return expandTabs(dL), expandTabs(dR), dT },
@@ -71,6 +73,26 @@ var (
 	}
 )
 
+// WithTheme highlights differences using the Styles of the given Theme:
+// "diff.deleted", "diff.inserted" and "diff.different" decorate the
+// differences, "diff.missing" decorates missing content and
+// "diff.deleted.marker", "diff.inserted.marker" and "diff.different.marker"
+// decorate the difference's type.
+func WithTheme(th ansi.Theme) Highlighter {
+	return Highlighter{
+		Same: func(dL, dR, dT string) (string, string, string) { return dL, dR, dT },
+		Deleted: func(dL, dR, dT string) (string, string, string) {
+			return th.Print("diff.deleted", dL), th.Print("diff.missing", dR), th.Print("diff.deleted.marker", dT)
+		},
+		Inserted: func(dL, dR, dT string) (string, string, string) {
+			return th.Print("diff.missing", dL), th.Print("diff.inserted", dR), th.Print("diff.inserted.marker", dT)
+		},
+		Different: func(dL, dR, dT string) (string, string, string) {
+			return th.Print("diff.different", dL), th.Print("diff.different", dR), th.Print("diff.different.marker", dT)
+		},
+	}
+}
+
 // Highlighter represents a set of functions to decorate a Diff when pretty
 // printing it.
 type Highlighter struct {
@@ -125,5 +147,7 @@ func showNonPrintable(s string) string {
 }
 
 func expandTabs(s string) string {
-	return strings.ReplaceAll(s, "\t", "    ")
+	pf := visual.DefaultProfile
+	pf.TabWidth = softTabWidth
+	return pf.ExpandTabs(s)
 }
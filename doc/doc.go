@@ -0,0 +1,124 @@
+// Package doc provides a simple document model to describe command-line
+// tools' documentation (sections made of paragraphs, definition lists,
+// option lists, tables and examples) that can be rendered as terminal text
+// (like for a --help output), as a man page (troff) or as Markdown from the
+// same content.
+package doc
+
+import (
+	"strings"
+)
+
+// Document represents a command-line tool's documentation.
+type Document struct {
+	// Name is the documented tool's name.
+	Name string
+	// Section is the manual's section the Document belongs to (like "1" for
+	// user commands).
+	Section string
+	// Date is the Document's last modification date.
+	Date string
+	// Source is the source of the documented tool (like its version).
+	Source string
+	// Manual is the title of the manual the Document belongs to.
+	Manual string
+
+	// Sections are the Document's sections.
+	Sections []*Section
+}
+
+// New creates a new empty Document for the given tool's name, belonging to
+// the given manual's section.
+func New(name, section string) *Document {
+	return &Document{
+		Name:    name,
+		Section: section,
+	}
+}
+
+// SetDate sets the Document's last modification date.
+func (d *Document) SetDate(date string) *Document {
+	d.Date = date
+	return d
+}
+
+// SetSource sets the Document's source (like the documented tool's version).
+func (d *Document) SetSource(source string) *Document {
+	d.Source = source
+	return d
+}
+
+// SetManual sets the title of the manual the Document belongs to.
+func (d *Document) SetManual(manual string) *Document {
+	d.Manual = manual
+	return d
+}
+
+// AddSection adds a new section to the Document. A section with an empty
+// title is displayed without heading (like the usage line of a --help
+// output).
+func (d *Document) AddSection(title string, blocks ...Block) *Document {
+	d.Sections = append(d.Sections, &Section{Title: title, Blocks: blocks})
+	return d
+}
+
+// Section represents a Document's section.
+type Section struct {
+	// Title is the section's title.
+	Title string
+	// Blocks are the section's content.
+	Blocks []Block
+}
+
+// Block is a block of content of a Document's section: Paragraph,
+// DefinitionList, OptionList, Table or Example.
+type Block interface {
+	isBlock()
+}
+
+// Paragraph is a paragraph of text. Text is wrapped when rendered, new lines
+// are considered as spaces.
+type Paragraph string
+
+// Example is a preformatted block of text like a code snippet or a command
+// example. Example is rendered as is.
+type Example string
+
+// Definition is a term and its description.
+type Definition struct {
+	Term        string
+	Description string
+}
+
+// DefinitionList is a list of terms and their descriptions.
+type DefinitionList []Definition
+
+// Option is a command-line option.
+type Option struct {
+	// Flags are the option's flags (like "-v", "--verbose").
+	Flags []string
+	// Arg is the name of the option's argument if any.
+	Arg string
+	// Description is the option's description.
+	Description string
+}
+
+// OptionList is a list of command-line options.
+type OptionList []Option
+
+// Table is a table made of a header and rows of cells.
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+func (Paragraph) isBlock()      {}
+func (Example) isBlock()        {}
+func (DefinitionList) isBlock() {}
+func (OptionList) isBlock()     {}
+func (Table) isBlock()          {}
+
+// paragraphText returns a Paragraph's text as a single line.
+func paragraphText(p Paragraph) string {
+	return strings.Join(strings.Fields(string(p)), " ")
+}
@@ -0,0 +1,154 @@
+package doc
+
+import (
+	"strings"
+)
+
+// Man renders a Document as a man page using troff's man macros. Tables rely
+// on the tbl preprocessor, that is requested by the page's first line if the
+// Document contains a Table.
+func Man(d *Document) string {
+	header := []string{strings.ToUpper(d.Name), d.Section, d.Date, d.Source, d.Manual}
+	// trailing empty fields are omitted.
+	for len(header) > 0 && header[len(header)-1] == "" {
+		header = header[:len(header)-1]
+	}
+	for i, h := range header {
+		header[i] = manQuote(h)
+	}
+
+	var out []string
+	if hasTable(d) {
+		out = append(out, `'\" t`)
+	}
+
+	out = append(out, strings.Join(append([]string{".TH"}, header...), " "))
+	for _, s := range d.Sections {
+		if s.Title != "" {
+			out = append(out, ".SH "+manQuote(strings.ToUpper(s.Title)))
+		}
+
+		for _, b := range s.Blocks {
+			out = append(out, manBlock(b))
+		}
+	}
+
+	return strings.Join(out, "\n") + "\n"
+}
+
+// hasTable reports whether a Document contains a Table.
+func hasTable(d *Document) bool {
+	for _, s := range d.Sections {
+		for _, b := range s.Blocks {
+			if _, ok := b.(Table); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func manBlock(b Block) string {
+	switch b := b.(type) {
+	case Paragraph:
+		return ".PP\n" + manText(string(b))
+
+	case Example:
+		lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+		for i, l := range lines {
+			lines[i] = manLine(manEscape(l))
+		}
+		return ".PP\n.RS 4\n.nf\n" + strings.Join(lines, "\n") + "\n.fi\n.RE"
+
+	case DefinitionList:
+		items := make([]string, len(b))
+		for i, def := range b {
+			items[i] = manItem(`\fB`+manEscape(def.Term)+`\fR`, def.Description)
+		}
+		return strings.Join(items, "\n")
+
+	case OptionList:
+		items := make([]string, len(b))
+		for i, opt := range b {
+			flags := make([]string, len(opt.Flags))
+			for j, f := range opt.Flags {
+				flags[j] = `\fB` + manEscape(f) + `\fR`
+			}
+
+			term := strings.Join(flags, ", ")
+			if opt.Arg != "" {
+				term += ` \fI` + manEscape(opt.Arg) + `\fR`
+			}
+
+			items[i] = manItem(term, opt.Description)
+		}
+		return strings.Join(items, "\n")
+
+	case Table:
+		ncol := len(b.Header)
+		for _, row := range b.Rows {
+			if len(row) > ncol {
+				ncol = len(row)
+			}
+		}
+
+		out := []string{".PP", ".TS"}
+		if len(b.Header) > 0 {
+			out = append(out, strings.TrimSpace(strings.Repeat("lb ", ncol)))
+		}
+		out = append(out, strings.TrimSpace(strings.Repeat("l ", ncol))+".")
+
+		if len(b.Header) > 0 {
+			out = append(out, manRow(b.Header), "_")
+		}
+		for _, row := range b.Rows {
+			out = append(out, manRow(row))
+		}
+
+		return strings.Join(append(out, ".TE"), "\n")
+	}
+
+	return ""
+}
+
+// manItem formats a definition list's or an option list's item as a tagged
+// paragraph.
+func manItem(term, description string) string {
+	if description == "" {
+		return ".TP\n" + manLine(term)
+	}
+	return ".TP\n" + manLine(term) + "\n" + manText(description)
+}
+
+// manRow formats a table's row for tbl.
+func manRow(row []string) string {
+	cells := make([]string, len(row))
+	for i, c := range row {
+		cells[i] = manEscape(strings.Join(strings.Fields(c), " "))
+	}
+	return manLine(strings.Join(cells, "\t"))
+}
+
+// manText formats a paragraph of text.
+func manText(s string) string {
+	return manLine(manEscape(paragraphText(Paragraph(s))))
+}
+
+// manLine protects a line of text that would be interpreted as a troff
+// request or a control line.
+func manLine(s string) string {
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		return `\&` + s
+	}
+	return s
+}
+
+// manQuote escapes and quotes a macro's argument.
+func manQuote(s string) string {
+	return `"` + strings.Replace(manEscape(s), `"`, `\(dq`, -1) + `"`
+}
+
+// manEscape escapes troff's special characters.
+func manEscape(s string) string {
+	return strings.NewReplacer(`\`, `\e`, `-`, `\-`).Replace(s)
+}
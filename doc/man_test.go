@@ -0,0 +1,65 @@
+package doc
+
+import (
+	"testing"
+)
+
+func TestMan(t *testing.T) {
+	want := `'\" t
+.TH "MYTOOL" "1" "2023\-01\-01" "mytool 1.0"
+.PP
+Usage: mytool [OPTIONS] FILE...
+.SH "DESCRIPTION"
+.PP
+mytool does many things to your files, in a way that is hopefully useful.
+.PP
+\&.hidden files are ignored.
+.SH "OPTIONS"
+.TP
+\fB\-v\fR, \fB\-\-verbose\fR
+Print more information.
+.TP
+\fB\-o\fR, \fB\-\-output\fR \fIFILE\fR
+Write the result to FILE instead of the standard output.
+.TP
+\fB\-h\fR
+.SH "FORMATS"
+.TP
+\fBtxt\fR
+Plain text.
+.TP
+\fBmarkdown\fR
+Markdown *text*.
+.PP
+.TS
+lb lb
+l l.
+Format	Extension
+_
+txt	.txt
+markdown	.md|.markdown
+.TE
+.SH "EXAMPLES"
+.PP
+.RS 4
+.nf
+$ mytool \-v file.txt
+
+$ mytool \-o out.txt in.txt
+.fi
+.RE
+`
+
+	if got := Man(testDoc); got != want {
+		t.Errorf("Fail to render document as man page.\nWant:\n%s\nGot :\n%s", want, got)
+	}
+}
+
+func TestManWithoutTable(t *testing.T) {
+	d := &Document{Name: "mytool", Section: "1", Sections: []*Section{{Blocks: []Block{Paragraph("Hello.")}}}}
+	want := ".TH \"MYTOOL\" \"1\"\n.PP\nHello.\n"
+
+	if got := Man(d); got != want {
+		t.Errorf("Fail to render document as man page.\nWant:\n%s\nGot :\n%s", want, got)
+	}
+}
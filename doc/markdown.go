@@ -0,0 +1,151 @@
+package doc
+
+import (
+	"strings"
+)
+
+// Markdown renders a Document as Markdown (CommonMark with pipe tables).
+func Markdown(d *Document) string {
+	var out []string
+
+	if d.Name != "" {
+		title := "# " + mdEscape(d.Name)
+		if d.Section != "" {
+			title += "(" + mdEscape(d.Section) + ")"
+		}
+		out = append(out, title)
+	}
+
+	for _, s := range d.Sections {
+		if s.Title != "" {
+			out = append(out, "## "+mdEscape(s.Title))
+		}
+
+		for _, b := range s.Blocks {
+			out = append(out, mdBlock(b))
+		}
+	}
+
+	if len(out) == 0 {
+		return ""
+	}
+	return strings.Join(out, "\n\n") + "\n"
+}
+
+func mdBlock(b Block) string {
+	switch b := b.(type) {
+	case Paragraph:
+		return mdText(string(b))
+
+	case Example:
+		fence := "```"
+		for strings.Contains(string(b), fence) {
+			fence += "`"
+		}
+		return fence + "\n" + strings.TrimSuffix(string(b), "\n") + "\n" + fence
+
+	case DefinitionList:
+		items := make([]string, len(b))
+		for i, def := range b {
+			items[i] = mdItem("**"+mdEscape(def.Term)+"**", def.Description)
+		}
+		return strings.Join(items, "\n")
+
+	case OptionList:
+		items := make([]string, len(b))
+		for i, opt := range b {
+			flags := make([]string, len(opt.Flags))
+			for j, f := range opt.Flags {
+				flags[j] = mdCode(f)
+			}
+
+			term := strings.Join(flags, ", ")
+			if opt.Arg != "" {
+				term += " *" + mdEscape(opt.Arg) + "*"
+			}
+
+			items[i] = mdItem(term, opt.Description)
+		}
+		return strings.Join(items, "\n")
+
+	case Table:
+		ncol := len(b.Header)
+		for _, row := range b.Rows {
+			if len(row) > ncol {
+				ncol = len(row)
+			}
+		}
+
+		out := []string{mdRow(b.Header, ncol), "|" + strings.Repeat(" --- |", ncol)}
+		for _, row := range b.Rows {
+			out = append(out, mdRow(row, ncol))
+		}
+		return strings.Join(out, "\n")
+	}
+
+	return ""
+}
+
+// mdItem formats a definition list's or an option list's item as a list
+// item, its description following the term after a hard line break.
+func mdItem(term, description string) string {
+	if description == "" {
+		return "- " + term
+	}
+	return "- " + term + "  \n  " + mdText(description)
+}
+
+// mdRow formats a table's row of ncol cells.
+func mdRow(row []string, ncol int) string {
+	var out strings.Builder
+
+	out.WriteString("|")
+	for i := 0; i < ncol; i++ {
+		var cell string
+		if i < len(row) {
+			cell = strings.Replace(mdEscape(paragraphText(Paragraph(row[i]))), "|", `\|`, -1)
+		}
+		out.WriteString(" " + cell + " |")
+	}
+
+	return out.String()
+}
+
+// mdText formats a paragraph of text, protecting it from being interpreted as
+// a block-level element (heading, list, quote...).
+func mdText(s string) string {
+	s = mdEscape(paragraphText(Paragraph(s)))
+
+	if strings.HasPrefix(s, "#") || strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		return `\` + s
+	}
+
+	// ordered list item's marker.
+	n := len(s) - len(strings.TrimLeft(s, "0123456789"))
+	if n > 0 && n < len(s) && (s[n] == '.' || s[n] == ')') {
+		return s[:n] + `\` + s[n:]
+	}
+
+	return s
+}
+
+// mdCode formats s as a code span.
+func mdCode(s string) string {
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return fence + " " + s + " " + fence
+	}
+	return fence + s + fence
+}
+
+// mdEscape escapes Markdown's inline special characters.
+func mdEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`,
+		`[`, `\[`, `]`, `\]`, `<`, `\<`, `>`, `\>`,
+	).Replace(s)
+}
@@ -0,0 +1,46 @@
+package doc
+
+import (
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	want := "# mytool(1)\n\n" +
+		"Usage: mytool \\[OPTIONS\\] FILE...\n\n" +
+		"## Description\n\n" +
+		"mytool does many things to your files, in a way that is hopefully useful.\n\n" +
+		".hidden files are ignored.\n\n" +
+		"## Options\n\n" +
+		"- `-v`, `--verbose`  \n  Print more information.\n" +
+		"- `-o`, `--output` *FILE*  \n  Write the result to FILE instead of the standard output.\n" +
+		"- `-h`\n\n" +
+		"## Formats\n\n" +
+		"- **txt**  \n  Plain text.\n" +
+		"- **markdown**  \n  Markdown \\*text\\*.\n\n" +
+		"| Format | Extension |\n| --- | --- |\n| txt | .txt |\n| markdown | .md\\|.markdown |\n\n" +
+		"## Examples\n\n" +
+		"```\n$ mytool -v file.txt\n\n$ mytool -o out.txt in.txt\n```\n"
+
+	if got := Markdown(testDoc); got != want {
+		t.Errorf("Fail to render document as Markdown.\nWant:\n%s\nGot :\n%s", want, got)
+	}
+}
+
+func TestMdText(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{"# not a title", `\# not a title`},
+		{"- not a list", `\- not a list`},
+		{"1. not a list", `1\. not a list`},
+		{"2023 is a year.", "2023 is a year."},
+		{"<html> & [link](url)", `\<html\> & \[link\](url)`},
+	}
+
+	for _, tc := range testCases {
+		if got := mdText(tc.in); got != tc.want {
+			t.Errorf("Fail to format '%s' as Markdown.\nWant: %s\nGot : %s", tc.in, tc.want, got)
+		}
+	}
+}
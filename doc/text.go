@@ -0,0 +1,164 @@
+package doc
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pirmd/text"
+	"github.com/pirmd/text/ansi"
+	"github.com/pirmd/text/table"
+)
+
+const (
+	// DefaultMaxWidth is the default maximum width of Documents rendered as
+	// text.
+	DefaultMaxWidth = 80
+
+	// sectionIndent is the indentation of a section's content.
+	sectionIndent = "  "
+	// exampleIndent is the additional indentation of an Example.
+	exampleIndent = "    "
+)
+
+var (
+	// DefaultTheme is the Theme used by a TextRenderer if none is provided.
+	DefaultTheme = ansi.Theme{
+		"doc.title":    {Attr: ansi.AttrBold},
+		"doc.term":     {Attr: ansi.AttrBold},
+		"doc.flag":     {Attr: ansi.AttrBold},
+		"doc.arg":      {Underline: ansi.SingleUnderline},
+		"doc.example":  {Attr: ansi.AttrFaint},
+		"table.header": {Attr: ansi.AttrBold},
+	}
+)
+
+// TextRenderer renders a Document as text for terminals, like for a --help
+// output.
+type TextRenderer struct {
+	out io.Writer
+
+	maxwidth int
+	theme    ansi.Theme
+}
+
+// NewTextRenderer creates a new TextRenderer that writes to out.
+func NewTextRenderer(out io.Writer) *TextRenderer {
+	return &TextRenderer{
+		out:      out,
+		maxwidth: DefaultMaxWidth,
+		theme:    DefaultTheme,
+	}
+}
+
+// SetMaxWidth sets the maximum width of the rendered text.
+func (r *TextRenderer) SetMaxWidth(maxwidth int) *TextRenderer {
+	r.maxwidth = maxwidth
+	return r
+}
+
+// SetTheme sets the Theme used to decorate the text. TextRenderer uses Styles
+// named "doc.title", "doc.term", "doc.flag", "doc.arg", "doc.example" as well
+// as table's Styles.
+// Default to DefaultTheme.
+func (r *TextRenderer) SetTheme(th ansi.Theme) *TextRenderer {
+	r.theme = th
+	return r
+}
+
+// Render renders a Document to the TextRenderer's output.
+//
+// Sections' titles are followed by their content, indented. Definition lists
+// and option lists are laid out using text.DefList: descriptions are aligned
+// next to their term, terms larger than text.DefaultMaxTagWidth being
+// displayed on their own line.
+func (r *TextRenderer) Render(d *Document) error {
+	sections := make([]string, 0, len(d.Sections))
+	for _, s := range d.Sections {
+		sections = append(sections, r.renderSection(s))
+	}
+
+	if len(sections) == 0 {
+		return nil
+	}
+
+	_, err := io.WriteString(r.out, strings.Join(sections, "\n\n")+"\n")
+	return err
+}
+
+// Text renders a Document as text of the given maximum width, using the
+// DefaultTheme.
+func Text(d *Document, maxwidth int) string {
+	var out strings.Builder
+	_ = NewTextRenderer(&out).SetMaxWidth(maxwidth).Render(d)
+	return out.String()
+}
+
+func (r *TextRenderer) renderSection(s *Section) string {
+	var indent string
+	var out []string
+
+	if s.Title != "" {
+		indent = sectionIndent
+		out = append(out, r.theme.Print("doc.title", s.Title))
+	}
+
+	for i, b := range s.Blocks {
+		if i > 0 {
+			out = append(out, "")
+		}
+		out = append(out, r.renderBlock(b, indent))
+	}
+
+	return strings.Join(out, "\n")
+}
+
+func (r *TextRenderer) renderBlock(b Block, indent string) string {
+	switch b := b.(type) {
+	case Paragraph:
+		return text.Tab(paragraphText(b), indent, indent, r.maxwidth)
+
+	case Example:
+		lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+		for i, l := range lines {
+			if l != "" {
+				lines[i] = indent + exampleIndent + r.theme.Print("doc.example", l)
+			}
+		}
+		return strings.Join(lines, "\n")
+
+	case DefinitionList:
+		l := text.NewDefList().SetMaxWidth(r.maxwidth).SetIndent(indent)
+		for _, def := range b {
+			l.Add(r.theme.Print("doc.term", def.Term), paragraphText(Paragraph(def.Description)))
+		}
+		return strings.TrimSuffix(l.String(), "\n")
+
+	case OptionList:
+		l := text.NewDefList().SetMaxWidth(r.maxwidth).SetIndent(indent)
+		for _, opt := range b {
+			l.Add(r.optionTerm(opt), paragraphText(Paragraph(opt.Description)))
+		}
+		return strings.TrimSuffix(l.String(), "\n")
+
+	case Table:
+		tab := table.New().SetMaxWidth(r.maxwidth - len(indent)).SetTheme(r.theme)
+		tab.SetHeader(b.Header...).AddRows(b.Rows...)
+		return text.Indent(strings.TrimSuffix(tab.String(), "\n"), indent, indent)
+	}
+
+	return ""
+}
+
+// optionTerm returns the decorated flags and argument of an Option.
+func (r *TextRenderer) optionTerm(opt Option) string {
+	flags := make([]string, len(opt.Flags))
+	for i, f := range opt.Flags {
+		flags[i] = r.theme.Print("doc.flag", f)
+	}
+
+	term := strings.Join(flags, ", ")
+	if opt.Arg != "" {
+		term += " " + r.theme.Print("doc.arg", opt.Arg)
+	}
+	return term
+}
@@ -0,0 +1,67 @@
+package doc
+
+import (
+	"strings"
+	"testing"
+)
+
+var testDoc = New("mytool", "1").SetDate("2023-01-01").SetSource("mytool 1.0").
+	AddSection("", Paragraph("Usage: mytool [OPTIONS] FILE...")).
+	AddSection("Description",
+		Paragraph("mytool does\nmany things to your files, in a way that is hopefully useful."),
+		Paragraph(".hidden files are ignored."),
+	).
+	AddSection("Options", OptionList{
+		{Flags: []string{"-v", "--verbose"}, Description: "Print more information."},
+		{Flags: []string{"-o", "--output"}, Arg: "FILE", Description: "Write the result to FILE instead of the standard output."},
+		{Flags: []string{"-h"}},
+	}).
+	AddSection("Formats",
+		DefinitionList{
+			{Term: "txt", Description: "Plain text."},
+			{Term: "markdown", Description: "Markdown *text*."},
+		},
+		Table{Header: []string{"Format", "Extension"}, Rows: [][]string{{"txt", ".txt"}, {"markdown", ".md|.markdown"}}},
+	).
+	AddSection("Examples", Example("$ mytool -v file.txt\n\n$ mytool -o out.txt in.txt\n"))
+
+func TestText(t *testing.T) {
+	want := `Usage: mytool [OPTIONS] FILE...
+
+Description
+  mytool does many things to your files, in a
+  way that is hopefully useful.
+
+  .hidden files are ignored.
+
+Options
+  -v, --verbose      Print more information.
+  -o, --output FILE  Write the result to FILE
+                     instead of the standard
+                     output.
+  -h
+
+Formats
+  txt       Plain text.
+  markdown  Markdown *text*.
+
+  Format   Extension    
+  txt      .txt         
+  markdown .md|.markdown
+
+Examples
+      $ mytool -v file.txt
+
+      $ mytool -o out.txt in.txt
+`
+
+	if got := textOf(testDoc, 48); got != want {
+		t.Errorf("Fail to render document as text.\nWant:\n%s\nGot :\n%s", want, got)
+	}
+}
+
+func textOf(d *Document, maxwidth int) string {
+	var out strings.Builder
+	_ = NewTextRenderer(&out).SetMaxWidth(maxwidth).SetTheme(nil).Render(d)
+	return out.String()
+}
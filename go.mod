@@ -5,6 +5,6 @@ go 1.15
 require (
 	github.com/antzucaro/matchr v0.0.0-20210222213004-b04723ef80f0
 	github.com/mattn/go-runewidth v0.0.14
-	github.com/rivo/uniseg v0.4.4 // indirect
+	github.com/rivo/uniseg v0.4.4
 	golang.org/x/term v0.5.0
 )
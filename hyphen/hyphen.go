@@ -0,0 +1,219 @@
+// Package hyphen implements the Knuth–Liang hyphenation algorithm, as used by
+// TeX, to find where words can be split by inserting an hyphen.
+//
+// It embeds US English patterns (see English) and knows how to load TeX
+// hyphenation pattern files (see Load) for other languages.
+package hyphen
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultLeftMin is the default minimum number of letters that are kept
+	// before an hyphen.
+	DefaultLeftMin = 2
+	// DefaultRightMin is the default minimum number of letters that are kept
+	// after an hyphen.
+	DefaultRightMin = 3
+)
+
+var (
+	english     *Patterns
+	englishOnce sync.Once
+)
+
+// Patterns is an hyphenation engine based on a set of Knuth–Liang
+// hyphenation patterns and on a list of exceptions.
+type Patterns struct {
+	// LeftMin is the minimum number of letters that are kept before an
+	// hyphen.
+	LeftMin int
+	// RightMin is the minimum number of letters that are kept after an
+	// hyphen.
+	RightMin int
+
+	// patterns maps a pattern's letters to its inter-letters values.
+	patterns map[string][]int
+	// exceptions maps a word to its hyphenation points (expressed in
+	// letters).
+	exceptions map[string][]int
+	// maxLen is the number of letters of the longest pattern.
+	maxLen int
+}
+
+// New creates a new hyphenation engine from a list of patterns (like
+// "a1bo", ".ach4" or "4b1d") and of exceptions (words whose hyphenation points
+// are explicitly given, like "ta-ble").
+func New(patterns []string, exceptions []string) (*Patterns, error) {
+	p := &Patterns{
+		LeftMin:    DefaultLeftMin,
+		RightMin:   DefaultRightMin,
+		patterns:   make(map[string][]int),
+		exceptions: make(map[string][]int),
+	}
+
+	for _, pattern := range patterns {
+		if err := p.AddPattern(pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, exception := range exceptions {
+		p.AddException(exception)
+	}
+
+	return p, nil
+}
+
+// English returns the hyphenation engine for US English. It only relies on
+// Liang's patterns, words that they do not hyphenate correctly can be given
+// using AddException.
+func English() *Patterns {
+	englishOnce.Do(func() {
+		var err error
+		english, err = New(strings.Fields(enUSPatterns), nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	return english
+}
+
+// AddPattern adds a new hyphenation pattern. A pattern is made of letters
+// interleaved with digits, odd digits marking acceptable hyphenation points
+// and even digits unacceptable ones. '.' marks the beginning or end of word.
+func (p *Patterns) AddPattern(pattern string) error {
+	var letters strings.Builder
+	values := []int{0}
+
+	for _, c := range strings.ToLower(pattern) {
+		if c >= '0' && c <= '9' {
+			values[len(values)-1] = int(c - '0')
+			continue
+		}
+
+		if c != '.' && !unicode.IsLetter(c) && !unicode.Is(unicode.Mn, c) {
+			return fmt.Errorf("invalid hyphenation pattern '%s'", pattern)
+		}
+
+		letters.WriteRune(c)
+		values = append(values, 0)
+	}
+
+	if letters.Len() == 0 {
+		return fmt.Errorf("invalid hyphenation pattern '%s'", pattern)
+	}
+
+	p.patterns[letters.String()] = values
+	if l := len(values) - 1; l > p.maxLen {
+		p.maxLen = l
+	}
+
+	return nil
+}
+
+// AddException adds a word whose hyphenation points are explicitly given by
+// '-' (like "pro-gram").
+func (p *Patterns) AddException(exception string) {
+	var word strings.Builder
+	var points []int
+
+	n := 0
+	for _, c := range strings.ToLower(exception) {
+		if c == '-' {
+			points = append(points, n)
+			continue
+		}
+		word.WriteRune(c)
+		n++
+	}
+
+	p.exceptions[word.String()] = points
+}
+
+// Hyphenate returns the offsets (in bytes) at which word can be split by
+// inserting an hyphen.
+// Each sequence of letters of word is hyphenated separately, so that
+// punctuation or existing hyphens are kept untouched.
+func (p *Patterns) Hyphenate(word string) (offsets []int) {
+	start := -1
+	for i, c := range word + " " {
+		isLetter := i < len(word) && (unicode.IsLetter(c) || unicode.Is(unicode.Mn, c))
+
+		switch {
+		case isLetter && start < 0:
+			start = i
+
+		case !isLetter && start >= 0:
+			offsets = append(offsets, p.hyphenate(word, start, i)...)
+			start = -1
+		}
+	}
+
+	return
+}
+
+// hyphenate returns the hyphenation offsets of the sequence of letters of
+// word found between start and end.
+func (p *Patterns) hyphenate(word string, start, end int) (offsets []int) {
+	letters := []rune(strings.ToLower(word[start:end]))
+	if len(letters) < p.LeftMin+p.RightMin {
+		return nil
+	}
+
+	points, ok := p.exceptions[string(letters)]
+	if !ok {
+		points = p.points(letters)
+	}
+
+	// convert letters' position into word's offsets
+	pos, offset := 0, start
+	for _, pt := range points {
+		if pt < p.LeftMin || pt > len(letters)-p.RightMin {
+			continue
+		}
+		for ; pos < pt; pos++ {
+			_, sz := utf8.DecodeRuneInString(word[offset:])
+			offset += sz
+		}
+		offsets = append(offsets, offset)
+	}
+
+	return
+}
+
+// points returns the hyphenation points (expressed in letters) of a word
+// according to Patterns.
+func (p *Patterns) points(letters []rune) (points []int) {
+	w := append(append([]rune{'.'}, letters...), '.')
+	values := make([]int, len(w)+1)
+
+	for i := range w {
+		for j := i + 1; j <= len(w) && j-i <= p.maxLen; j++ {
+			pattern, ok := p.patterns[string(w[i:j])]
+			if !ok {
+				continue
+			}
+			for k, v := range pattern {
+				if v > values[i+k] {
+					values[i+k] = v
+				}
+			}
+		}
+	}
+
+	// values[i] is the value before w[i], that is to say before letters[i-1].
+	for i := 1; i < len(letters); i++ {
+		if values[i+1]%2 == 1 {
+			points = append(points, i)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,69 @@
+package hyphen
+
+import (
+	"strings"
+	"testing"
+)
+
+// hyphenated returns word with an hyphen inserted at each offset.
+func hyphenated(word string, offsets []int) string {
+	var s strings.Builder
+	last := 0
+	for _, i := range offsets {
+		s.WriteString(word[last:i])
+		s.WriteByte('-')
+		last = i
+	}
+	s.WriteString(word[last:])
+	return s.String()
+}
+
+func TestEnglish(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{"hyphenation", "hy-phen-ation"},
+		{"algorithm", "al-go-rithm"},
+		{"typesetting", "type-set-ting"},
+		{"International", "In-ter-na-tional"},
+		{"encyclopedia", "en-cy-clo-pe-dia"},
+		{"academy", "academy"},
+		{"table", "table"},
+		{"cat", "cat"},
+		{"self-programming,", "self-pro-gram-ming,"},
+	}
+
+	for _, tc := range testCases {
+		if got := hyphenated(tc.in, English().Hyphenate(tc.in)); got != tc.want {
+			t.Errorf("Hyphenation of '%s' failed.\nWant: %s\nGot : %s", tc.in, tc.want, got)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	p, err := New([]string{"1ba", "é1"}, []string{"ba-ba-bé"})
+	if err != nil {
+		t.Fatalf("Fail to create hyphenation patterns: %v", err)
+	}
+	p.LeftMin, p.RightMin = 1, 1
+
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{"abababa", "a-ba-ba-ba"},
+		{"ébaé", "é-baé"},
+		{"bababé", "ba-ba-bé"},
+	}
+
+	for _, tc := range testCases {
+		if got := hyphenated(tc.in, p.Hyphenate(tc.in)); got != tc.want {
+			t.Errorf("Hyphenation of '%s' failed.\nWant: %s\nGot : %s", tc.in, tc.want, got)
+		}
+	}
+
+	if _, err := New([]string{"a1-b"}, nil); err == nil {
+		t.Errorf("Invalid hyphenation pattern is not detected")
+	}
+}
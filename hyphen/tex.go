@@ -0,0 +1,63 @@
+package hyphen
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Load creates a new hyphenation engine from a TeX hyphenation patterns file
+// (like hyph-utf8's "hyph-fr.tex"). Patterns are read from the \patterns{}
+// command and exceptions from the \hyphenation{} command. Comments ('%') are
+// ignored as well as any other TeX command.
+func Load(r io.Reader) (*Patterns, error) {
+	var patterns, exceptions []string
+	var cur *[]string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '%'); i >= 0 {
+			line = line[:i]
+		}
+
+		for _, word := range strings.Fields(line) {
+			for word != "" {
+				switch {
+				case strings.HasPrefix(word, `\patterns{`):
+					cur, word = &patterns, word[len(`\patterns{`):]
+
+				case strings.HasPrefix(word, `\hyphenation{`):
+					cur, word = &exceptions, word[len(`\hyphenation{`):]
+
+				case cur == nil:
+					word = ""
+
+				default:
+					i := strings.IndexByte(word, '}')
+					if i < 0 {
+						*cur = append(*cur, word)
+						word = ""
+						continue
+					}
+
+					if i > 0 {
+						*cur = append(*cur, word[:i])
+					}
+					cur, word = nil, word[i+1:]
+				}
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("no hyphenation patterns found")
+	}
+
+	return New(patterns, exceptions)
+}
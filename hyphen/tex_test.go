@@ -0,0 +1,40 @@
+package hyphen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	tex := `% A tiny set of patterns
+\message{Tiny patterns}
+\patterns{ % patterns
+1ba
+e1 }
+\hyphenation{ba-bab
+}`
+
+	p, err := Load(strings.NewReader(tex))
+	if err != nil {
+		t.Fatalf("Fail to load TeX patterns: %v", err)
+	}
+	p.LeftMin, p.RightMin = 1, 1
+
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{"abebe", "abe-be"},
+		{"babab", "ba-bab"},
+	}
+
+	for _, tc := range testCases {
+		if got := hyphenated(tc.in, p.Hyphenate(tc.in)); got != tc.want {
+			t.Errorf("Hyphenation of '%s' failed.\nWant: %s\nGot : %s", tc.in, tc.want, got)
+		}
+	}
+
+	if _, err := Load(strings.NewReader(`\message{no patterns}`)); err == nil {
+		t.Errorf("Missing patterns are not detected")
+	}
+}
@@ -0,0 +1,182 @@
+// Package mdblock recognizes Markdown-like blocks (quotes, lists, code
+// fences, headings...) so that text.Reflow and the markdown package agree on
+// the structure of a text.
+package mdblock
+
+import (
+	"strings"
+
+	"github.com/pirmd/text/visual"
+)
+
+// Starts reports whether a line starts a new block that interrupts the
+// current paragraph.
+func Starts(line string) bool {
+	if LeadingSpaces(line) >= 4 {
+		return false
+	}
+
+	content := strings.TrimLeft(line, " ")
+	return Fence(content) != "" || HeadingLevel(content) > 0 || IsRule(content) ||
+		IsQuote(content) || ListMarker(content) != ""
+}
+
+// Quote returns the content of the block quote that starts at lines[i], quote
+// markers removed, and the index of the first line that follows it.
+func Quote(lines []string, i int) (body []string, j int) {
+	for j = i; j < len(lines); j++ {
+		l := strings.TrimLeft(lines[j], " ")
+		switch {
+		case IsQuote(l):
+			body = append(body, strings.TrimPrefix(l[1:], " "))
+			continue
+
+		// lazy continuation of the quoted paragraph.
+		case !IsBlank(l) && len(body) > 0 && !IsBlank(body[len(body)-1]) && !Starts(lines[j]):
+			body = append(body, l)
+			continue
+		}
+		break
+	}
+	return
+}
+
+// Item returns the content of the list item that starts at lines[i] and whose
+// content starts at column col, as well as the index of the first line that
+// follows it.
+func Item(lines []string, i int, col int) (body []string, j int) {
+	body = []string{""}
+	if col < len(lines[i]) {
+		body[0] = lines[i][col:]
+	}
+
+	for j = i + 1; j < len(lines); j++ {
+		l := lines[j]
+		if IsBlank(l) {
+			// blank lines belong to the item if it goes on after them.
+			k := j
+			for k < len(lines) && IsBlank(lines[k]) {
+				k++
+			}
+			if k == len(lines) || LeadingSpaces(lines[k]) < col {
+				break
+			}
+			body = append(body, "")
+			continue
+		}
+
+		if LeadingSpaces(l) >= col {
+			body = append(body, l[col:])
+			continue
+		}
+
+		// lazy continuation of the item's paragraph.
+		if !IsBlank(body[len(body)-1]) && !Starts(l) {
+			body = append(body, strings.TrimLeft(l, " "))
+			continue
+		}
+
+		break
+	}
+	return
+}
+
+// ListMarker returns the list item's marker that starts s ('-', '*', '+', '•'
+// or a number followed by '.' or ')') or an empty string if s does not start
+// by a list item.
+func ListMarker(s string) string {
+	n := 0
+	switch {
+	case strings.HasPrefix(s, "-"), strings.HasPrefix(s, "*"), strings.HasPrefix(s, "+"):
+		n = 1
+
+	case strings.HasPrefix(s, "•"):
+		n = len("•")
+
+	default:
+		for n < len(s) && n < 9 && s[n] >= '0' && s[n] <= '9' {
+			n++
+		}
+		if n == 0 || n == len(s) || (s[n] != '.' && s[n] != ')') {
+			return ""
+		}
+		n++
+	}
+
+	if n < len(s) && s[n] != ' ' && s[n] != '\t' {
+		return ""
+	}
+	return s[:n]
+}
+
+// ListItem returns the marker of the list item that starts line, after its
+// indentation, and the column where the item's content starts. marker is
+// empty if line does not start by a list item.
+func ListItem(line string) (marker string, col int) {
+	indentation := LeadingSpaces(line)
+	if marker = ListMarker(line[indentation:]); marker == "" {
+		return "", 0
+	}
+
+	col = indentation + len(marker)
+	spaces := LeadingSpaces(line[col:])
+	if col+spaces == len(line) || spaces > 4 {
+		return marker, col + 1
+	}
+	return marker, col + spaces
+}
+
+// Fence returns the code fence ("```" or "~~~", possibly longer) that starts
+// s or an empty string if s does not start by a code fence.
+func Fence(s string) string {
+	for _, c := range []string{"`", "~"} {
+		if n := len(s) - len(strings.TrimLeft(s, c)); n >= 3 {
+			if c == "`" && strings.Contains(s[n:], "`") {
+				return ""
+			}
+			return s[:n]
+		}
+	}
+	return ""
+}
+
+// IsClosingFence reports whether line closes the code block opened by fence.
+func IsClosingFence(line string, fence string) bool {
+	l := strings.TrimSpace(line)
+	return strings.HasPrefix(l, fence) && strings.Trim(l, fence[:1]) == ""
+}
+
+// HeadingLevel returns the level of the ATX heading that starts s or 0 if s
+// is not an heading.
+func HeadingLevel(s string) int {
+	n := len(s) - len(strings.TrimLeft(s, "#"))
+	if n == 0 || n > 6 || (n < len(s) && s[n] != ' ' && s[n] != '\t') {
+		return 0
+	}
+	return n
+}
+
+// IsRule reports whether s is a thematic break (like "---", "***" or "_ _ _").
+func IsRule(s string) bool {
+	s = strings.Replace(strings.Replace(strings.TrimSpace(s), " ", "", -1), "\t", "", -1)
+	if len(s) < 3 {
+		return false
+	}
+	return strings.Trim(s, s[:1]) == "" && strings.ContainsAny(s[:1], "-*_")
+}
+
+// IsQuote reports whether s starts by a block quote marker.
+func IsQuote(s string) bool {
+	return strings.HasPrefix(s, ">")
+}
+
+// IsBlank reports whether s is made of spaces only (ANSI escape sequences
+// excepted).
+func IsBlank(s string) bool {
+	return len(visual.TrimSpace([]byte(s))) == 0
+}
+
+// LeadingSpaces returns the number of spaces s starts with.
+func LeadingSpaces(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " "))
+}
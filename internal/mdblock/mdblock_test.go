@@ -0,0 +1,55 @@
+package mdblock
+
+import (
+	"testing"
+)
+
+func TestListItem(t *testing.T) {
+	testCases := []struct {
+		in     string
+		marker string
+		col    int
+	}{
+		{"- item", "-", 2},
+		{"  * item", "*", 4},
+		{"• item", "•", len("• ")},
+		{"12. item", "12.", 4},
+		{"1)   item", "1)", 5},
+		{"-      code", "-", 2},
+		{"-", "-", 2},
+		{"-item", "", 0},
+		{"1.item", "", 0},
+		{"item", "", 0},
+	}
+
+	for _, tc := range testCases {
+		marker, col := ListItem(tc.in)
+		if marker != tc.marker || col != tc.col {
+			t.Errorf("ListItem failed for %#v.\nWant: %#v, %d\nGot : %#v, %d\n", tc.in, tc.marker, tc.col, marker, col)
+		}
+	}
+}
+
+func TestStarts(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want bool
+	}{
+		{"- item", true},
+		{"> quote", true},
+		{"```go", true},
+		{"~~~", true},
+		{"## heading", true},
+		{"---", true},
+		{"text", false},
+		{"#hashtag", false},
+		{"``` not `a fence", false},
+		{"    - indented code", false},
+	}
+
+	for _, tc := range testCases {
+		if got := Starts(tc.in); got != tc.want {
+			t.Errorf("Starts failed for %#v.\nWant: %v\nGot : %v\n", tc.in, tc.want, got)
+		}
+	}
+}
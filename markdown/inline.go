@@ -0,0 +1,306 @@
+package markdown
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pirmd/text/ansi"
+)
+
+// asciiPunctuation lists the characters that can be escaped by a backslash.
+const asciiPunctuation = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+// inline renders Markdown's inline elements (emphasis, code spans, links...)
+// of s as ANSI-decorated text.
+// Soft line breaks are replaced by a space, hard line breaks (a line ending
+// by two spaces or by a backslash) by an end-of-line.
+func (r *Renderer) inline(s string) string {
+	var out strings.Builder
+	var text strings.Builder
+
+	flushText := func() {
+		out.WriteString(html.UnescapeString(text.String()))
+		text.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte(asciiPunctuation, s[i+1]) >= 0:
+			flushText()
+			out.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			flushText()
+			out.WriteByte('\n')
+			i += 2
+			continue
+
+		case c == '\n':
+			t := strings.TrimRight(text.String(), " ")
+			isHardBreak := strings.HasSuffix(text.String(), "  ")
+			text.Reset()
+			text.WriteString(t)
+			flushText()
+			if isHardBreak {
+				out.WriteByte('\n')
+			} else {
+				out.WriteByte(' ')
+			}
+			i++
+			continue
+
+		case c == '`':
+			if code, n := codeSpan(s[i:]); n > 0 {
+				flushText()
+				out.WriteString(r.theme.Print("markdown.code", code))
+				i += n
+				continue
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			if inner, delim, n := r.emphasis(s, i); n > 0 {
+				flushText()
+				out.WriteString(r.theme.Print(emphasisStyle(delim), inner))
+				i += n
+				continue
+			}
+
+		case c == '!' && strings.HasPrefix(s[i:], "!["):
+			if label, url, n := link(s[i+1:]); n > 0 {
+				flushText()
+				out.WriteString(r.link(url, r.inline(label)))
+				i += n + 1
+				continue
+			}
+
+		case c == '[':
+			if label, url, n := link(s[i:]); n > 0 {
+				flushText()
+				out.WriteString(r.link(url, r.inline(label)))
+				i += n
+				continue
+			}
+
+		case c == '<':
+			if url, n := autolink(s[i:]); n > 0 {
+				flushText()
+				out.WriteString(r.link(url, strings.TrimPrefix(url, "mailto:")))
+				i += n
+				continue
+			}
+		}
+
+		// text is made of whole runes and not only of bytes to keep
+		// multi-bytes runes and HTML entities untouched.
+		_, sz := utf8.DecodeRuneInString(s[i:])
+		text.WriteString(s[i : i+sz])
+		i += sz
+	}
+
+	flushText()
+	return out.String()
+}
+
+// link renders a link.
+func (r *Renderer) link(url, label string) string {
+	if r.hyperlinks {
+		return ansi.Hyperlink(url, r.theme.Print("markdown.link", label))
+	}
+
+	if label == url || label == strings.TrimPrefix(url, "mailto:") {
+		return r.theme.Print("markdown.link", label)
+	}
+	return r.theme.Print("markdown.link", label) + " (" + url + ")"
+}
+
+// emphasis looks for an emphasis (or a strikethrough) that starts at s[i]. It
+// returns the emphasized text, rendered, its delimiter and the length of the
+// emphasis in s or 0 if s[i] does not start an emphasis.
+func (r *Renderer) emphasis(s string, i int) (string, string, int) {
+	c := s[i]
+	n := len(s[i:]) - len(strings.TrimLeft(s[i:], string(c)))
+	if n > 3 || (c == '~' && n != 2) {
+		return "", "", 0
+	}
+	delim := s[i : i+n]
+
+	// an opening delimiter is followed by a non space character and, for
+	// '_', is not preceded by a letter or a digit.
+	next, _ := utf8.DecodeRuneInString(s[i+n:])
+	prev, _ := utf8.DecodeLastRuneInString(s[:i])
+	if i+n == len(s) || unicode.IsSpace(next) || (c == '_' && i > 0 && isAlnum(prev)) {
+		return "", "", 0
+	}
+
+	for j := i + n; j < len(s); j++ {
+		switch {
+		case s[j] == '\\':
+			j++
+
+		case s[j] == '`':
+			if _, l := codeSpan(s[j:]); l > 0 {
+				j += l - 1
+			}
+
+		case strings.HasPrefix(s[j:], delim):
+			// a closing delimiter is exactly made of delim, is preceded
+			// by a non space character and, for '_', is not followed by a
+			// letter or a digit.
+			if strings.HasPrefix(s[j+n:], string(c)) {
+				j += len(s[j:]) - len(strings.TrimLeft(s[j:], string(c))) - 1
+				continue
+			}
+
+			prev, _ := utf8.DecodeLastRuneInString(s[:j])
+			next, _ := utf8.DecodeRuneInString(s[j+n:])
+			if unicode.IsSpace(prev) || (c == '_' && j+n < len(s) && isAlnum(next)) {
+				continue
+			}
+
+			return r.inline(s[i+n : j]), delim, j + n - i
+		}
+	}
+
+	return "", "", 0
+}
+
+// emphasisStyle returns the name of the Style used for an emphasis delimited
+// by delim.
+func emphasisStyle(delim string) string {
+	switch {
+	case delim == "~~":
+		return "markdown.strikethrough"
+	case len(delim) == 1:
+		return "markdown.emphasis"
+	case len(delim) == 2:
+		return "markdown.strong"
+	}
+	return "markdown.strongemphasis"
+}
+
+// codeSpan looks for a code span that starts s. It returns the code and the
+// length of the code span in s or 0 if s does not start by a code span.
+func codeSpan(s string) (string, int) {
+	n := len(s) - len(strings.TrimLeft(s, "`"))
+	delim := s[:n]
+
+	for j := n; j < len(s); {
+		k := strings.Index(s[j:], delim)
+		if k < 0 {
+			break
+		}
+		j += k
+
+		// closing delimiter is a backtick string of the same length.
+		l := len(s[j:]) - len(strings.TrimLeft(s[j:], "`"))
+		if l != n {
+			j += l
+			continue
+		}
+
+		code := strings.Replace(s[n:j], "\n", " ", -1)
+		if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+			code = code[1 : len(code)-1]
+		}
+		return code, j + n
+	}
+
+	return "", 0
+}
+
+// link looks for a link ("[label](url "title")") that starts s. It returns
+// the link's label, its url and the length of the link in s or 0 if s does not
+// start by a link.
+func link(s string) (string, string, int) {
+	depth, end := 0, -1
+	for i := 0; i < len(s) && end < 0; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				end = i
+			}
+		}
+	}
+
+	if end < 0 || !strings.HasPrefix(s[end+1:], "(") {
+		return "", "", 0
+	}
+
+	depth, closing := 0, -1
+	for i := end + 1; i < len(s) && closing < 0; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				closing = i
+			}
+		}
+	}
+
+	if closing < 0 {
+		return "", "", 0
+	}
+
+	dest := strings.TrimSpace(s[end+2 : closing])
+	if strings.HasPrefix(dest, "<") {
+		if i := strings.IndexByte(dest, '>'); i > 0 {
+			dest = dest[1:i]
+		}
+	} else if i := strings.IndexAny(dest, " \n"); i >= 0 {
+		// drop link's title
+		dest = dest[:i]
+	}
+
+	return s[1:end], dest, closing + 1
+}
+
+// autolink looks for an autolink ("<scheme:...>" or "<user@example.com>")
+// that starts s. It returns the link's url and the length of the autolink in s
+// or 0 if s does not start by an autolink.
+func autolink(s string) (string, int) {
+	end := strings.IndexByte(s, '>')
+	if end < 0 {
+		return "", 0
+	}
+
+	url := s[1:end]
+	if url == "" || strings.ContainsAny(url, " <\n") {
+		return "", 0
+	}
+
+	if i := strings.IndexByte(url, ':'); i > 1 && isScheme(url[:i]) {
+		return url, end + 1
+	}
+
+	if i := strings.IndexByte(url, '@'); i > 0 && strings.Contains(url[i:], ".") {
+		return "mailto:" + url, end + 1
+	}
+
+	return "", 0
+}
+
+func isScheme(s string) bool {
+	for i, c := range s {
+		if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && (i == 0 || !strings.ContainsRune("0123456789+.-", c)) {
+			return false
+		}
+	}
+	return len(s) <= 32
+}
+
+func isAlnum(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c)
+}
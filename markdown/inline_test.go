@@ -0,0 +1,31 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/pirmd/text/visual"
+)
+
+func TestInline(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{"Some *emphasis*, __strong__ and ***both***", "Some emphasis, strong and both"},
+		{"snake_case_word and _emphasis_", "snake_case_word and emphasis"},
+		{"a * b * c and ~~gone~~", "a * b * c and gone"},
+		{"`code *not emphasis*` and `` a ` b ``", "code *not emphasis* and a ` b"},
+		{"\\*escaped\\* &amp; &copy;", "*escaped* & ©"},
+		{"soft\nbreak  \nhard\\\nbreak", "soft break\nhard\nbreak"},
+		{"a [link](http://example.com \"title\") and ![img](a.png)", "a link (http://example.com) and img (a.png)"},
+		{"<http://example.com> and <me@example.com>", "http://example.com and me@example.com"},
+		{"[not a link] and <not autolink>", "[not a link] and <not autolink>"},
+	}
+
+	r := New(nil).SetProfile(visual.Profile{}).SetTheme(nil).SetHyperlinks(false)
+	for _, tc := range testCases {
+		if got := r.inline(tc.in); got != tc.want {
+			t.Errorf("Fail to render inline '%s'.\nWant: %q\nGot : %q", tc.in, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+// Package markdown renders Markdown (CommonMark) text for terminals: text is
+// wrapped and indented to fit a given width and decorated using ANSI escape
+// sequences.
+//
+// Supported elements are headings, paragraphs, emphasis, code spans and
+// blocks, block quotes, (nested) lists, thematic breaks, links (rendered as
+// OSC 8 hyperlinks) and tables. Raw HTML is displayed as is.
+package markdown
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pirmd/text/ansi"
+	"github.com/pirmd/text/table"
+	"github.com/pirmd/text/txtwriter"
+	"github.com/pirmd/text/visual"
+)
+
+const (
+	// DefaultMaxWidth is the default maximum width of the rendered text.
+	DefaultMaxWidth = 80
+
+	// codeIndent is the indentation of code blocks.
+	codeIndent = "    "
+
+	// minWidth is the minimum width left to the text of nested blocks so
+	// that deeply nested quotes, lists or tables are still wrapped into
+	// readable lines, even if it means exceeding the maximum width.
+	minWidth = 10
+)
+
+var (
+	// DefaultTheme is the Theme used by a Renderer if none is provided.
+	// It only relies on text attributes and should fit any terminal's
+	// colors.
+	DefaultTheme = ansi.Theme{
+		"markdown.heading1":       {Attr: ansi.AttrBold, Underline: ansi.SingleUnderline},
+		"markdown.heading2":       {Attr: ansi.AttrBold},
+		"markdown.heading":        {Attr: ansi.AttrBold | ansi.AttrItalic},
+		"markdown.emphasis":       {Attr: ansi.AttrItalic},
+		"markdown.strong":         {Attr: ansi.AttrBold},
+		"markdown.strongemphasis": {Attr: ansi.AttrBold | ansi.AttrItalic},
+		"markdown.strikethrough":  {Attr: ansi.AttrCrossedOut},
+		"markdown.code":           {Attr: ansi.AttrInverse},
+		"markdown.codeblock":      {Attr: ansi.AttrFaint},
+		"markdown.link":           {Underline: ansi.SingleUnderline},
+		"markdown.quote":          {Attr: ansi.AttrFaint},
+		"markdown.bullet":         {Attr: ansi.AttrBold},
+		"markdown.rule":           {Attr: ansi.AttrFaint},
+		"table.header":            {Attr: ansi.AttrBold},
+	}
+)
+
+// Renderer renders Markdown text for terminals.
+type Renderer struct {
+	out io.Writer
+
+	maxwidth   int
+	profile    visual.Profile
+	theme      ansi.Theme
+	hyperlinks bool
+}
+
+// New creates a new Renderer that writes to out.
+func New(out io.Writer) *Renderer {
+	return &Renderer{
+		out:        out,
+		maxwidth:   DefaultMaxWidth,
+		profile:    visual.DefaultProfile,
+		theme:      DefaultTheme,
+		hyperlinks: true,
+	}
+}
+
+// SetMaxWidth sets the maximum width of the rendered text.
+func (r *Renderer) SetMaxWidth(maxwidth int) *Renderer {
+	r.maxwidth = maxwidth
+	return r
+}
+
+// SetProfile sets the Profile used to measure the text's "visual" width.
+// Default to visual.DefaultProfile.
+func (r *Renderer) SetProfile(pf visual.Profile) *Renderer {
+	r.profile = pf
+	return r
+}
+
+// SetTheme sets the Theme used to decorate the text. Renderer uses Styles
+// named "markdown.heading1", "markdown.heading2", "markdown.heading" (other
+// levels), "markdown.emphasis", "markdown.strong", "markdown.strongemphasis",
+// "markdown.strikethrough", "markdown.code", "markdown.codeblock",
+// "markdown.link", "markdown.quote", "markdown.bullet", "markdown.rule" as
+// well as table's Styles.
+// Default to DefaultTheme.
+func (r *Renderer) SetTheme(th ansi.Theme) *Renderer {
+	r.theme = th
+	return r
+}
+
+// SetHyperlinks governs the way links are rendered: either as OSC 8
+// hyperlinks (default) or by displaying the link's url after its text for
+// terminals that do not support hyperlinks.
+func (r *Renderer) SetHyperlinks(enable bool) *Renderer {
+	r.hyperlinks = enable
+	return r
+}
+
+// Render renders Markdown text to the Renderer's output.
+func (r *Renderer) Render(src string) error {
+	src = r.profile.ExpandTabs(strings.Replace(src, "\r\n", "\n", -1))
+	blocks := parse(strings.Split(src, "\n"))
+
+	out := r.render(blocks, "", "", false)
+	if out == "" {
+		return nil
+	}
+
+	_, err := io.WriteString(r.out, out+"\n")
+	return err
+}
+
+// Render renders Markdown text for a terminal of the given width, using the
+// DefaultTheme.
+func Render(src string, maxwidth int) string {
+	var out strings.Builder
+	_ = New(&out).SetMaxWidth(maxwidth).Render(src)
+	return out.String()
+}
+
+// render renders a list of blocks, each line being prefixed by rest except
+// for the first one that is prefixed by first. Blocks are separated by blank
+// lines, except for tight blocks.
+func (r *Renderer) render(blocks []*block, first, rest string, tight bool) string {
+	rendered := make([]string, 0, len(blocks))
+	for i, b := range blocks {
+		if i > 0 {
+			first = rest
+			if !tight {
+				rendered = append(rendered, r.blankLine(rest))
+			}
+		}
+		rendered = append(rendered, r.renderBlock(b, first, rest))
+	}
+
+	return strings.Join(rendered, "\n")
+}
+
+func (r *Renderer) renderBlock(b *block, first, rest string) string {
+	switch b.kind {
+	case headingBlock:
+		style := "markdown.heading"
+		if b.level <= 2 {
+			style = fmt.Sprintf("markdown.heading%d", b.level)
+		}
+		return r.wrap(r.theme.Print(style, r.inline(b.text)), first, rest)
+
+	case codeBlock:
+		lines := strings.Split(b.text, "\n")
+		for i, l := range lines {
+			lines[i] = r.theme.Print("markdown.codeblock", l)
+		}
+		return r.write(strings.Join(lines, "\n"), first+codeIndent, rest+codeIndent)
+
+	case ruleBlock:
+		var out strings.Builder
+		w := r.newWriter(&out, first, rest).NoWrap()
+		_, _ = w.Write([]byte(r.theme.Print("markdown.rule", r.profile.Repeat("─", w.Width()))))
+		_ = w.Flush()
+		return out.String()
+
+	case quoteBlock:
+		prefix := r.theme.Print("markdown.quote", "│") + " "
+		return r.render(b.children, first+prefix, rest+prefix, false)
+
+	case listBlock:
+		return r.renderList(b, first, rest)
+
+	case tableBlock:
+		return r.renderTable(b, first, rest)
+	}
+
+	return r.wrap(r.inline(b.text), first, rest)
+}
+
+func (r *Renderer) renderList(b *block, first, rest string) string {
+	// markers are aligned on the right.
+	markers := make([]string, len(b.items))
+	var markerWidth int
+	for i := range b.items {
+		markers[i] = "•"
+		if b.ordered {
+			markers[i] = fmt.Sprintf("%d.", b.start+i)
+		}
+		if w := r.profile.Stringwidth(markers[i]); w > markerWidth {
+			markerWidth = w
+		}
+	}
+
+	prefix := strings.Repeat(" ", markerWidth+1)
+	items := make([]string, 0, len(b.items))
+	for i, item := range b.items {
+		if i > 0 {
+			first = rest
+			if b.loose {
+				items = append(items, r.blankLine(rest))
+			}
+		}
+
+		tag := strings.Repeat(" ", markerWidth-r.profile.Stringwidth(markers[i]))
+		tag += r.theme.Print("markdown.bullet", markers[i]) + " "
+		items = append(items, r.render(item, first+tag, rest+prefix, !b.loose))
+	}
+
+	return strings.Join(items, "\n")
+}
+
+func (r *Renderer) renderTable(b *block, first, rest string) string {
+	rows := make([][]string, len(b.rows))
+	for i, row := range b.rows {
+		rows[i] = make([]string, len(b.rows[0]))
+		for j := range rows[i] {
+			if j < len(row) {
+				rows[i][j] = r.inline(row[j])
+			}
+		}
+	}
+
+	var out strings.Builder
+	w := r.newWriter(&out, first, rest).NoWrap()
+
+	tab := table.New().SetMaxWidth(w.Width()).SetProfile(r.profile).SetTheme(r.theme)
+	tab.SetGrid(&table.Grid{Columns: " │ ", Header: "─"})
+	tab.SetHeader(rows[0]...)
+	tab.AddRows(rows[1:]...)
+
+	_, _ = w.Write([]byte(strings.TrimSuffix(tab.String(), "\n")))
+	_ = w.Flush()
+	return out.String()
+}
+
+// newWriter returns a txtwriter.Writer that writes to out lines of the
+// Renderer's maximum width, prefixed by first for the first one and by rest
+// for the following ones. Lines are made wider if the prefixes leave less
+// than minWidth to the text.
+func (r *Renderer) newWriter(out io.Writer, first, rest string) *txtwriter.Writer {
+	maxwidth := r.maxwidth
+	if maxwidth > 0 {
+		prefixWidth := r.profile.Stringwidth(first)
+		if w := r.profile.Stringwidth(rest); w > prefixWidth {
+			prefixWidth = w
+		}
+		if maxwidth-prefixWidth < minWidth {
+			maxwidth = prefixWidth + minWidth
+		}
+	}
+
+	return txtwriter.New(out).SetMaxWidth(maxwidth).SetProfile(r.profile).SetPrefix(first, rest).TrimBlankPrefix()
+}
+
+// wrap wraps and prefixes text using a txtwriter.Writer.
+func (r *Renderer) wrap(s string, first, rest string) string {
+	var out strings.Builder
+
+	w := r.newWriter(&out, first, rest).LazyWrap()
+	_, _ = w.Write([]byte(s))
+	_ = w.Flush()
+
+	return out.String()
+}
+
+// write prefixes preformatted text using a txtwriter.Writer.
+func (r *Renderer) write(s string, first, rest string) string {
+	var out strings.Builder
+
+	w := r.newWriter(&out, first, rest).NoWrap()
+	_, _ = w.Write([]byte(s))
+	_ = w.Flush()
+
+	return out.String()
+}
+
+// blankLine returns a blank line prefixed by prefix.
+func (r *Renderer) blankLine(prefix string) string {
+	var out strings.Builder
+
+	w := r.newWriter(&out, prefix, prefix).NoWrap()
+	_, _ = w.Write([]byte{'\n'})
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
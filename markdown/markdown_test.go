@@ -0,0 +1,161 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pirmd/text/ansi"
+	"github.com/pirmd/text/visual"
+)
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{
+			"# Title\n\nSome text that is long enough to be wrapped.",
+			"Title\n\nSome text that is long enough to\nbe wrapped.\n",
+		},
+		{
+			"- item that is long enough to be wrapped\n- item\n  1. nested\n  2. nested",
+			"• item that is long enough to be\n  wrapped\n• item\n  1. nested\n  2. nested\n",
+		},
+		{
+			"1. item\n\n2. item\n",
+			"1. item\n\n2. item\n",
+		},
+		{
+			"8. item\n9. item\n10. item",
+			" 8. item\n 9. item\n10. item\n",
+		},
+		{
+			"> quoted text that is long enough to be wrapped\n>\n> > nested",
+			"│ quoted text that is long enough\n│ to be wrapped\n│\n│ │ nested\n",
+		},
+		{
+			"Code:\n\n```go\nfunc main() {\n\tfmt.Println()\n}\n```",
+			"Code:\n\n    func main() {\n            fmt.Println()\n    }\n",
+		},
+		{
+			"> - quoted item\n>\n>       code\n>         indented",
+			"│ • quoted item\n│       code\n│         indented\n",
+		},
+		{
+			"Hello\n\n---\n\nWorld",
+			"Hello\n\n" + strings.Repeat("─", 34) + "\n\nWorld\n",
+		},
+		{
+			"| a | b |\n|---|:-:|\n| 1 | two |",
+			"a │ b  \n─ │ ───\n1 │ two\n",
+		},
+		{
+			"A [link](https://example.com) and <https://go.dev>.",
+			"A link (https://example.com) and\nhttps://go.dev.\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		got := new(strings.Builder)
+		if err := New(got).SetMaxWidth(34).SetProfile(visual.Profile{}).SetTheme(nil).SetHyperlinks(false).Render(tc.in); err != nil {
+			t.Fatalf("Fail to render '%s': %v", tc.in, err)
+		}
+
+		if got.String() != tc.want {
+			t.Errorf("Fail to render '%s'.\nWant:\n%#v\n\nGot :\n%#v", tc.in, tc.want, got.String())
+		}
+	}
+}
+
+func TestRenderWithTheme(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{
+			"# Title",
+			"\x1b[1;4mTitle\x1b[0m\n",
+		},
+		{
+			"Some *emphasis*, **strong** and `code`.",
+			"Some \x1b[3memphasis\x1b[0m, \x1b[1mstrong\x1b[0m and \x1b[7mcode\x1b[0m.\n",
+		},
+		{
+			"A [link](https://example.com).",
+			"A \x1b]8;;https://example.com\x1b\\\x1b[4mlink\x1b[0m\x1b]8;;\x1b\\.\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		got := new(strings.Builder)
+		if err := New(got).SetMaxWidth(40).SetProfile(visual.Profile{}).Render(tc.in); err != nil {
+			t.Fatalf("Fail to render '%s': %v", tc.in, err)
+		}
+
+		if got.String() != tc.want {
+			t.Errorf("Fail to render '%s'.\nWant:\n%#v\n\nGot :\n%#v", tc.in, tc.want, got.String())
+		}
+	}
+}
+
+func TestRenderWithoutHyperlinks(t *testing.T) {
+	in := "A [link](https://example.com)."
+	want := "A \x1b[4mlink\x1b[0m (https://example.com).\n"
+
+	got := new(strings.Builder)
+	if err := New(got).SetProfile(visual.Profile{}).SetHyperlinks(false).Render(in); err != nil {
+		t.Fatalf("Fail to render '%s': %v", in, err)
+	}
+
+	if got.String() != want {
+		t.Errorf("Fail to render '%s'.\nWant:\n%#v\n\nGot :\n%#v", in, want, got.String())
+	}
+}
+
+func TestRenderNarrow(t *testing.T) {
+	testCases := []struct {
+		in       string
+		maxwidth int
+		want     string
+	}{
+		{
+			"| a | b | c |\n|---|---|---|\n| 😀😀 | 日本語 | x |",
+			13,
+			"a   │ b   │ c\n─── │ ─── │ ─\n😀  │ 日  │ x\n😀  │ 本  │  \n    │ 語  │  \n",
+		},
+		{
+			"> > > | a | b |\n> > > |---|---|\n> > > | 😀😀 | 日本語 |",
+			6,
+			"│ │ │ a   │ b  \n│ │ │ ─── │ ───\n│ │ │ 😀  │ 日 \n│ │ │ 😀  │ 本 \n│ │ │     │ 語 \n",
+		},
+		{
+			"> > > > > 😀 日本語 text",
+			8,
+			"│ │ │ │ │ 😀 日本語\n│ │ │ │ │ text\n",
+		},
+		{
+			"- - - - 1. 😀 日本語 text",
+			8,
+			"• • • • 1. 😀 日本語\n           text\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		done := make(chan string, 1)
+		go func() {
+			got := new(strings.Builder)
+			_ = New(got).SetMaxWidth(tc.maxwidth).SetProfile(visual.Profile{}).SetTheme(ansi.Theme{}).Render(tc.in)
+			done <- got.String()
+		}()
+
+		select {
+		case got := <-done:
+			if got != tc.want {
+				t.Errorf("Fail to render '%s' at %d.\nWant:\n%#v\n\nGot :\n%#v", tc.in, tc.maxwidth, tc.want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Rendering '%s' at %d does not terminate", tc.in, tc.maxwidth)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pirmd/text/internal/mdblock"
+)
+
+type blockKind int
+
+const (
+	paragraphBlock blockKind = iota
+	headingBlock
+	codeBlock
+	ruleBlock
+	quoteBlock
+	listBlock
+	tableBlock
+)
+
+// block is a Markdown's block-level element.
+type block struct {
+	kind blockKind
+
+	// text is the inline content of a paragraph or a heading or the
+	// content of a code block.
+	text string
+	// level is the heading's level.
+	level int
+	// info is the fenced code block's info string.
+	info string
+
+	// children are the blocks contained in a block quote.
+	children []*block
+
+	// items are the blocks contained in each item of a list.
+	items [][]*block
+	// ordered is true for ordered lists, start giving the number of the
+	// first item.
+	ordered bool
+	start   int
+	// loose is true if the list's items are separated by blank lines.
+	loose bool
+
+	// rows are the cells of a table, the first row being its header.
+	rows [][]string
+}
+
+// parse splits Markdown's lines into blocks.
+func parse(lines []string) (blocks []*block) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		indentation := mdblock.LeadingSpaces(line)
+		content := line[indentation:]
+
+		switch {
+		case mdblock.IsBlank(line):
+			i++
+
+		case indentation >= 4:
+			var code []string
+			j := i
+			for ; j < len(lines) && (mdblock.IsBlank(lines[j]) || mdblock.LeadingSpaces(lines[j]) >= 4); j++ {
+				code = append(code, strings.TrimPrefix(lines[j], "    "))
+			}
+			for len(code) > 0 && mdblock.IsBlank(code[len(code)-1]) {
+				code, j = code[:len(code)-1], j-1
+			}
+			blocks = append(blocks, &block{kind: codeBlock, text: strings.Join(code, "\n")})
+			i = j
+
+		case mdblock.Fence(content) != "":
+			fence := mdblock.Fence(content)
+			var code []string
+			j := i + 1
+			for ; j < len(lines); j++ {
+				if mdblock.IsClosingFence(lines[j], fence) {
+					j++
+					break
+				}
+				code = append(code, trimIndentation(lines[j], indentation))
+			}
+			info := strings.TrimSpace(strings.TrimLeft(content, fence[:1]))
+			blocks = append(blocks, &block{kind: codeBlock, text: strings.Join(code, "\n"), info: info})
+			i = j
+
+		case mdblock.HeadingLevel(content) > 0:
+			level := mdblock.HeadingLevel(content)
+			text := strings.TrimSpace(content[level:])
+			if t := strings.TrimRight(text, "#"); t == "" || strings.HasSuffix(t, " ") {
+				text = strings.TrimSpace(t)
+			}
+			blocks = append(blocks, &block{kind: headingBlock, level: level, text: text})
+			i++
+
+		case mdblock.IsRule(content):
+			blocks = append(blocks, &block{kind: ruleBlock})
+			i++
+
+		case mdblock.IsQuote(content):
+			body, j := mdblock.Quote(lines, i)
+			blocks = append(blocks, &block{kind: quoteBlock, children: parse(body)})
+			i = j
+
+		case mdblock.ListMarker(content) != "":
+			list, j := parseList(lines, i)
+			blocks = append(blocks, list)
+			i = j
+
+		case i+1 < len(lines) && strings.Contains(line, "|") && isTableDelimiter(lines[i+1]):
+			rows := [][]string{splitRow(line)}
+			j := i + 2
+			for ; j < len(lines) && !mdblock.IsBlank(lines[j]) && strings.Contains(lines[j], "|"); j++ {
+				rows = append(rows, splitRow(lines[j]))
+			}
+			blocks = append(blocks, &block{kind: tableBlock, rows: rows})
+			i = j
+
+		default:
+			paragraph := []string{strings.TrimSpace(line)}
+			j := i + 1
+			for ; j < len(lines) && !mdblock.IsBlank(lines[j]); j++ {
+				if level := setextLevel(lines[j]); level > 0 {
+					blocks = append(blocks, &block{kind: headingBlock, level: level, text: strings.Join(paragraph, "\n")})
+					paragraph = nil
+					j++
+					break
+				}
+				if mdblock.Starts(lines[j]) {
+					break
+				}
+				paragraph = append(paragraph, strings.TrimLeft(lines[j], " "))
+			}
+			if paragraph != nil {
+				blocks = append(blocks, &block{kind: paragraphBlock, text: strings.Join(paragraph, "\n")})
+			}
+			i = j
+		}
+	}
+
+	return
+}
+
+// parseList parses the list that starts at lines[i]. It returns the list and
+// the index of the first line that follows it.
+func parseList(lines []string, i int) (*block, int) {
+	indentation := mdblock.LeadingSpaces(lines[i])
+	marker := mdblock.ListMarker(lines[i][indentation:])
+
+	list := &block{kind: listBlock}
+	if n, err := strconv.Atoi(marker[:len(marker)-1]); err == nil {
+		list.ordered, list.start = true, n
+	}
+
+	for i < len(lines) {
+		line := lines[i]
+		indentation := mdblock.LeadingSpaces(line)
+		m := mdblock.ListMarker(line[indentation:])
+		if m == "" || m[len(m)-1] != marker[len(marker)-1] {
+			break
+		}
+
+		_, col := mdblock.ListItem(line)
+		body, j := mdblock.Item(lines, i, col)
+
+		if len(body) > 1 && mdblock.IsBlank(body[len(body)-1]) {
+			list.loose = true
+		}
+		for k := 1; k < len(body)-1; k++ {
+			if mdblock.IsBlank(body[k]) && !mdblock.IsBlank(body[k+1]) && mdblock.LeadingSpaces(body[k+1]) == 0 {
+				list.loose = true
+			}
+		}
+		list.items = append(list.items, parse(body))
+
+		// items separated by blank lines make the list loose.
+		k := j
+		for k < len(lines) && mdblock.IsBlank(lines[k]) {
+			k++
+		}
+		if k > j && k < len(lines) && mdblock.ListMarker(strings.TrimLeft(lines[k], " ")) != "" && mdblock.LeadingSpaces(lines[k]) < col {
+			list.loose = true
+		}
+		if k < len(lines) && mdblock.LeadingSpaces(lines[k]) < col {
+			if m := mdblock.ListMarker(strings.TrimLeft(lines[k], " ")); m != "" && m[len(m)-1] == marker[len(marker)-1] {
+				j = k
+			}
+		}
+
+		i = j
+	}
+
+	return list, i
+}
+
+// setextLevel returns the level of the heading underlined by line ('=' for
+// level 1, '-' for level 2) or 0 if line is not an heading's underline.
+func setextLevel(line string) int {
+	if mdblock.LeadingSpaces(line) >= 4 {
+		return 0
+	}
+
+	switch l := strings.TrimSpace(line); {
+	case l == "":
+		return 0
+	case strings.Trim(l, "=") == "":
+		return 1
+	case strings.Trim(l, "-") == "":
+		return 2
+	}
+	return 0
+}
+
+// isTableDelimiter reports whether line is the delimiter row that separates
+// a table's header from its body (like "| --- | :---: |").
+func isTableDelimiter(line string) bool {
+	if !strings.Contains(line, "-") {
+		return false
+	}
+
+	for _, cell := range splitRow(line) {
+		cell = strings.TrimSuffix(strings.TrimPrefix(cell, ":"), ":")
+		if cell == "" || strings.Trim(cell, "-") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// splitRow splits a table's row into its cells.
+func splitRow(line string) (cells []string) {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// trimIndentation removes up to n leading spaces from line.
+func trimIndentation(line string, n int) string {
+	if l := mdblock.LeadingSpaces(line); l < n {
+		n = l
+	}
+	return line[n:]
+}
@@ -0,0 +1,73 @@
+package markdown
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want []*block
+	}{
+		{
+			"# Title #\n\nSome\ntext",
+			[]*block{{kind: headingBlock, level: 1, text: "Title"}, {kind: paragraphBlock, text: "Some\ntext"}},
+		},
+		{
+			"Title\n=====\nSub-title\n---",
+			[]*block{{kind: headingBlock, level: 1, text: "Title"}, {kind: headingBlock, level: 2, text: "Sub-title"}},
+		},
+		{
+			"Text\n***\n    code\n\n    more code\n\ntext",
+			[]*block{{kind: paragraphBlock, text: "Text"}, {kind: ruleBlock}, {kind: codeBlock, text: "code\n\nmore code"}, {kind: paragraphBlock, text: "text"}},
+		},
+		{
+			"~~~ sh\n$ ls\n~~~",
+			[]*block{{kind: codeBlock, text: "$ ls", info: "sh"}},
+		},
+		{
+			"> quote\nlazy\n> > nested",
+			[]*block{{kind: quoteBlock, children: []*block{{kind: paragraphBlock, text: "quote\nlazy"}, {kind: quoteBlock, children: []*block{{kind: paragraphBlock, text: "nested"}}}}}},
+		},
+		{
+			"- one\n- two\n  continued\n* other list",
+			[]*block{
+				{kind: listBlock, items: [][]*block{{{kind: paragraphBlock, text: "one"}}, {{kind: paragraphBlock, text: "two\ncontinued"}}}},
+				{kind: listBlock, items: [][]*block{{{kind: paragraphBlock, text: "other list"}}}},
+			},
+		},
+		{
+			"3) one\n\n4) two",
+			[]*block{{kind: listBlock, ordered: true, start: 3, loose: true, items: [][]*block{{{kind: paragraphBlock, text: "one"}}, {{kind: paragraphBlock, text: "two"}}}}},
+		},
+		{
+			"| a | b \\| c |\n| - | - |\n| 1 | 2 |",
+			[]*block{{kind: tableBlock, rows: [][]string{{"a", "b | c"}, {"1", "2"}}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := parse(strings.Split(tc.in, "\n")); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Fail to parse '%s'.\nWant:\n%s\n\nGot :\n%s", tc.in, dump(tc.want), dump(got))
+		}
+	}
+}
+
+// dump returns a readable representation of blocks to ease debugging.
+func dump(blocks []*block) string {
+	var s strings.Builder
+	for _, b := range blocks {
+		s.WriteString(fmt.Sprintf("{kind:%d level:%d text:%q info:%q ordered:%t start:%d loose:%t rows:%q", b.kind, b.level, b.text, b.info, b.ordered, b.start, b.loose, b.rows))
+		if len(b.children) > 0 {
+			s.WriteString(" children:[" + dump(b.children) + "]")
+		}
+		for _, item := range b.items {
+			s.WriteString(" item:[" + dump(item) + "]")
+		}
+		s.WriteString("}\n")
+	}
+	return s.String()
+}
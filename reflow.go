@@ -0,0 +1,132 @@
+package text
+
+import (
+	"strings"
+
+	"github.com/pirmd/text/internal/mdblock"
+	"github.com/pirmd/text/visual"
+)
+
+// Reflow wraps a text paragraph by paragraph so that each of its line's
+// "visual" length is lower or equal to the provided limit. Unlike Wrap that
+// considers the text as a single flow, Reflow preserves the text's structure:
+//   - blank lines separating paragraphs are kept,
+//   - paragraphs' indentation is kept, including hanging indentation (first
+//     line being less indented than the following ones),
+//   - list items (starting by '-', '*', '+', '•' or by a number followed by
+//     '.' or ')') are wrapped so that their text stays aligned after the
+//     bullet, nested lists included,
+//   - quoted blocks (lines starting by '>') are wrapped then quoted again,
+//   - headings (lines starting by '#') and thematic breaks (like "---") are
+//     kept as is,
+//   - preformatted blocks (lines indented by at least four spaces or fenced
+//     by "```" or "~~~") are kept as is.
+//
+// Tabs are expanded to the next tab stop before reflowing the text.
+func Reflow(s string, sz int) string {
+	in := visual.ExpandTabs(strings.TrimSuffix(s, "\n"))
+	out := strings.Join(reflow(strings.Split(in, "\n"), sz), "\n")
+
+	if strings.HasSuffix(s, "\n") {
+		out += "\n"
+	}
+	return out
+}
+
+func reflow(lines []string, sz int) (out []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		indentation := mdblock.LeadingSpaces(line)
+		content := line[indentation:]
+
+		switch {
+		case mdblock.IsBlank(line):
+			out = append(out, "")
+			i++
+
+		case indentation >= 4:
+			j := i + 1
+			for j < len(lines) && (mdblock.IsBlank(lines[j]) || mdblock.LeadingSpaces(lines[j]) >= 4) {
+				j++
+			}
+			// trailing blank lines are not part of the preformatted block.
+			for j > i+1 && mdblock.IsBlank(lines[j-1]) {
+				j--
+			}
+			out = append(out, lines[i:j]...)
+			i = j
+
+		case mdblock.Fence(content) != "":
+			fence := mdblock.Fence(content)
+			j := i + 1
+			for j < len(lines) && !mdblock.IsClosingFence(lines[j], fence) {
+				j++
+			}
+			if j < len(lines) {
+				j++
+			}
+			out = append(out, lines[i:j]...)
+			i = j
+
+		case mdblock.HeadingLevel(content) > 0, mdblock.IsRule(content):
+			out = append(out, line)
+			i++
+
+		case mdblock.IsQuote(content):
+			body, j := mdblock.Quote(lines, i)
+
+			prefix := line[:indentation] + "> "
+			for _, l := range reflow(body, sz-visual.Stringwidth(prefix)) {
+				if l == "" {
+					out = append(out, strings.TrimRight(prefix, " "))
+				} else {
+					out = append(out, prefix+l)
+				}
+			}
+			i = j
+
+		case mdblock.ListMarker(content) != "":
+			_, col := mdblock.ListItem(line)
+			body, j := mdblock.Item(lines, i, col)
+
+			tag, prefix := (line + " ")[:col], strings.Repeat(" ", col)
+			for k, l := range reflow(body, sz-col) {
+				switch {
+				case k == 0:
+					out = append(out, strings.TrimRight(tag+l, " "))
+				case l == "":
+					out = append(out, "")
+				default:
+					out = append(out, prefix+l)
+				}
+			}
+			i = j
+
+		default:
+			j := i + 1
+			for j < len(lines) && !mdblock.IsBlank(lines[j]) && !mdblock.Starts(lines[j]) {
+				j++
+			}
+
+			tag, prefix := line[:indentation], line[:indentation]
+			if j > i+1 {
+				prefix = strings.Repeat(" ", mdblock.LeadingSpaces(lines[i+1]))
+			}
+			w := len(tag)
+			if len(prefix) > w {
+				w = len(prefix)
+			}
+
+			paragraph := make([]string, 0, j-i)
+			for _, l := range lines[i:j] {
+				paragraph = append(paragraph, strings.TrimSpace(l))
+			}
+
+			wrapped := Wrap(strings.Join(paragraph, " "), sz-w)
+			out = append(out, strings.Split(indent(wrapped, tag, prefix), "\n")...)
+			i = j
+		}
+	}
+
+	return
+}
@@ -0,0 +1,83 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestReflow(t *testing.T) {
+	testCases := []struct {
+		in  string
+		sz  int
+		out string
+	}{
+		{"Coucou", 10, "Coucou"},
+		{"Coucou\n", 10, "Coucou\n"},
+		{
+			"This is a long\nsentence.\n\n\nAnd another one.",
+			12,
+			"This is a\nlong\nsentence.\n\n\nAnd another\none.",
+		},
+		{
+			"  This is an indented paragraph.",
+			14,
+			"  This is an\n  indented\n  paragraph.",
+		},
+		{
+			"Hanging: paragraph that is long enough\n         to be wrapped",
+			30,
+			"Hanging: paragraph\n         that is long enough\n         to be wrapped",
+		},
+		{
+			"- first item that is long enough\n- second item\n  1. nested item\n  2. second\n\n  more on second item",
+			20,
+			"- first item that is\n  long enough\n- second item\n  1. nested item\n  2. second\n\n  more on second\n  item",
+		},
+		{
+			"* item that is\nlazily continued",
+			20,
+			"* item that is\n  lazily continued",
+		},
+		{
+			"> quoted text that is long enough\n> again\n>\n> > nested",
+			20,
+			"> quoted text that\n> is long enough\n> again\n>\n> > nested",
+		},
+		{
+			"> quoted text that is\nlazily continued",
+			20,
+			"> quoted text that\n> is lazily\n> continued",
+		},
+		{
+			"# A heading that is long enough\nA paragraph\n***\n- item\n# Heading",
+			10,
+			"# A heading that is long enough\nA\nparagraph\n***\n- item\n# Heading",
+		},
+		{
+			"Some code:\n\n    func main() { fmt.Println(\"Hello world!\") }\n\n    // end\nDone",
+			20,
+			"Some code:\n\n    func main() { fmt.Println(\"Hello world!\") }\n\n    // end\nDone",
+		},
+		{
+			"```\nfunc main() { fmt.Println(\"Hello world!\") }\n```\nThis is a long sentence.",
+			20,
+			"```\nfunc main() { fmt.Println(\"Hello world!\") }\n```\nThis is a long\nsentence.",
+		},
+		{
+			"\tTabulated text is preformatted",
+			20,
+			"        Tabulated text is preformatted",
+		},
+		{
+			"This \x1b[34mis a long\nsentence\x1b[0m.",
+			10,
+			"This \x1b[34mis a\nlong\nsentence\x1b[0m.",
+		},
+	}
+
+	for _, tc := range testCases {
+		got := Reflow(tc.in, tc.sz)
+		if got != tc.out {
+			t.Errorf("Reflow to %d failed for %#v.\nWant:\n%#v\nGot :\n%#v\n", tc.sz, tc.in, tc.out, got)
+		}
+	}
+}
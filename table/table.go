@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/pirmd/text/ansi"
+	"github.com/pirmd/text/terminal"
 	"github.com/pirmd/text/visual"
 )
 
@@ -51,6 +52,13 @@ type Table struct {
 
 	// sep contains the patterns to draw the Table's grid.
 	sep *Grid
+
+	// profile is used to measure the "visual" width of the Table's cells.
+	profile visual.Profile
+
+	// theme contains the Styles used to decorate the Table's header
+	// ("table.header"), footer ("table.footer") and grid ("table.grid").
+	theme ansi.Theme
 }
 
 // New returns a new empty table, with no grid and a maximum width set-up to
@@ -59,6 +67,7 @@ func New() *Table {
 	return &Table{
 		maxWidth: DefaultMaxWidth,
 		sep:      &Grid{Columns: " "},
+		profile:  visual.DefaultProfile,
 	}
 }
 
@@ -68,6 +77,15 @@ func (t *Table) SetMaxWidth(w int) *Table {
 	return t
 }
 
+// SetTerminal sets the Table's maximum width to the width of the Terminal
+// the Table is to be displayed on, if it can be determined.
+func (t *Table) SetTerminal(term terminal.Terminal) *Table {
+	if w, _, ok := term.Size(); ok {
+		t.maxWidth = w
+	}
+	return t
+}
+
 // SetColWidth sets the table's column width. If not set, Table will
 // auto-determined the column width based on Table's max width.
 func (t *Table) SetColWidth(w ...int) *Table {
@@ -87,6 +105,20 @@ func (t *Table) SetGrid(sep *Grid) *Table {
 	return t
 }
 
+// SetTheme sets the Theme used to decorate the Table. Table uses
+// "table.header", "table.footer" and "table.grid" Styles.
+func (t *Table) SetTheme(th ansi.Theme) *Table {
+	t.theme = th
+	return t
+}
+
+// SetProfile sets the Profile used to measure the "visual" width of the
+// Table's cells. Default to visual.DefaultProfile.
+func (t *Table) SetProfile(pf visual.Profile) *Table {
+	t.profile = pf
+	return t
+}
+
 // SetHeader sets the Table's header (first row).
 func (t *Table) SetHeader(row ...string) *Table {
 	t.header = append([]string{}, row...)
@@ -147,7 +179,7 @@ func (t *Table) WriteTo(w io.Writer) (int64, error) {
 	sepFooter := t.buildSeparator(t.sep.Footer)
 
 	if len(t.header) > 0 {
-		n, err := t.writeRowTo(w, t.header)
+		n, err := t.writeRowTo(w, t.styleRow("table.header", t.header))
 		nbytes += n
 		if err != nil {
 			return int64(nbytes), err
@@ -188,7 +220,7 @@ func (t *Table) WriteTo(w io.Writer) (int64, error) {
 			}
 		}
 
-		n, err := t.writeRowTo(w, t.footer)
+		n, err := t.writeRowTo(w, t.styleRow("table.footer", t.footer))
 		nbytes += n
 		if err != nil {
 			return int64(nbytes), err
@@ -212,7 +244,7 @@ func (t *Table) writeRowTo(w io.Writer, row []string) (int, error) {
 
 		for j, cell := range subrow {
 			if j > 0 {
-				n, err := fmt.Fprint(w, t.sep.Columns)
+				n, err := fmt.Fprint(w, t.theme.Print("table.grid", t.sep.Columns))
 				nbytes += n
 				if err != nil {
 					return nbytes, err
@@ -232,11 +264,12 @@ func (t *Table) writeRowTo(w io.Writer, row []string) (int, error) {
 
 // padRow splits a row into a slice of cells. Each cells is right-aligned,
 // padded to fit table's column width ans any trailing new line is trimmed.
+// Tabs are expanded relatively to the cell's beginning.
 func (t *Table) padRow(row []string) [][]string {
 	// iterate over t.colWidth in the cases where row has missing columns
 	subrows := make([][]string, len(t.colWidth))
 	for i := range row {
-		subrows[i] = visual.Cut(row[i], t.colWidth[i])
+		subrows[i] = t.profile.Cut(t.profile.ExpandTabs(row[i]), t.colWidth[i])
 		for j := range subrows[i] {
 			subrows[i][j] = string(visual.TrimSuffix([]byte(subrows[i][j]), '\n'))
 		}
@@ -246,7 +279,7 @@ func (t *Table) padRow(row []string) [][]string {
 	paddedrows := col2rows(subrows)
 	for i := range paddedrows {
 		for j := range paddedrows[i] {
-			paddedrows[i][j] = string(visual.PadRight([]byte(paddedrows[i][j]), t.colWidth[j]))
+			paddedrows[i][j] = string(t.profile.PadRight([]byte(paddedrows[i][j]), t.colWidth[j]))
 		}
 	}
 	return paddedrows
@@ -259,10 +292,19 @@ func (t *Table) buildSeparator(pattern string) string {
 
 	sep := make([]string, len(t.colWidth))
 	for i := range t.colWidth {
-		sep[i] = visual.Repeat(pattern, t.colWidth[i])
+		sep[i] = t.profile.Repeat(pattern, t.colWidth[i])
 	}
 
-	return strings.Join(sep, t.sep.Columns)
+	return t.theme.Print("table.grid", strings.Join(sep, t.sep.Columns))
+}
+
+// styleRow decorates each row's cell with the Theme's Style of the given name.
+func (t *Table) styleRow(name string, row []string) []string {
+	styled := make([]string, len(row))
+	for i, cell := range row {
+		styled[i] = t.theme.Print(name, cell)
+	}
+	return styled
 }
 
 func (t *Table) writeSepTo(w io.Writer, sep string) (int, error) {
@@ -297,7 +339,7 @@ func (t *Table) writeSepTo(w io.Writer, sep string) (int, error) {
 func (t *Table) autoColWidth() {
 	t.colWidth = make([]int, len(t.header))
 	for i, cell := range t.header {
-		l := cellWidth(cell)
+		l := t.cellWidth(cell)
 
 		if i >= len(t.colWidth) {
 			t.colWidth = append(t.colWidth, l)
@@ -308,7 +350,7 @@ func (t *Table) autoColWidth() {
 
 	for _, row := range t.body {
 		for i, cell := range row {
-			l := cellWidth(cell)
+			l := t.cellWidth(cell)
 
 			if i >= len(t.colWidth) {
 				t.colWidth = append(t.colWidth, l)
@@ -319,7 +361,7 @@ func (t *Table) autoColWidth() {
 	}
 
 	for i, cell := range t.footer {
-		l := cellWidth(cell)
+		l := t.cellWidth(cell)
 
 		if i >= len(t.colWidth) {
 			t.colWidth = append(t.colWidth, l)
@@ -328,7 +370,7 @@ func (t *Table) autoColWidth() {
 		}
 	}
 
-	maxUsableWidth := t.maxWidth - (len(t.colWidth)-1)*visual.Stringwidth(t.sep.Columns)
+	maxUsableWidth := t.maxWidth - (len(t.colWidth)-1)*t.profile.Stringwidth(t.sep.Columns)
 	max := findWidthLimit(t.colWidth, maxUsableWidth)
 	for i, l := range t.colWidth {
 		if l > max {
@@ -376,10 +418,10 @@ func findWidthLimit(width []int, max int) int {
 	return m
 }
 
-func cellWidth(cell string) int {
+func (t *Table) cellWidth(cell string) int {
 	var length int
-	for _, line := range strings.Split(cell, "\n") {
-		if l := visual.Stringwidth(line); l > length {
+	for _, line := range strings.Split(t.profile.ExpandTabs(cell), "\n") {
+		if l := t.profile.Stringwidth(line); l > length {
 			length = l
 		}
 	}
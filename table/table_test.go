@@ -3,6 +3,10 @@ package table
 import (
 	"reflect"
 	"testing"
+
+	"github.com/pirmd/text/ansi"
+	"github.com/pirmd/text/terminal"
+	"github.com/pirmd/text/visual"
 )
 
 func TestCol2Rows(t *testing.T) {
@@ -248,3 +252,62 @@ func TestInterruptFormattingAtEOL(t *testing.T) {
 		}
 	}
 }
+
+func TestTableWithTheme(t *testing.T) {
+	th := ansi.Theme{
+		"table.header": {Attr: ansi.AttrBold},
+		"table.grid":   {FG: ansi.ColorBlue},
+	}
+
+	got := New().SetGrid(&Grid{Columns: "|", Header: "-"}).SetTheme(th).SetMaxWidth(24).SetHeader("A", "B").AddRows([]string{"val1", "val2"}).String()
+	want := "\x1b[1mA\x1b[0m   \x1b[34m|\x1b[0m\x1b[1mB\x1b[0m   \n\x1b[34m----|----\x1b[0m\nval1\x1b[34m|\x1b[0mval2"
+	if got != want {
+		t.Errorf("table failed with theme.\nWanted:\n%#v\nGot   :\n%#v\n", want, got)
+	}
+}
+
+func TestTableWithProfile(t *testing.T) {
+	row := []string{"§§", "x"}
+
+	testCases := []struct {
+		profile visual.Profile
+		want    string
+	}{
+		{visual.Profile{}, "§§|x\n--|-\n§§|x"},
+		{visual.Profile{AmbiguousWide: true}, "§§|x\n----|-\n§§|x"},
+	}
+
+	for _, tc := range testCases {
+		got := New().SetGrid(&Grid{Columns: "|", Header: "-"}).SetProfile(tc.profile).SetHeader(row...).AddRows(row).String()
+		if got != tc.want {
+			t.Errorf("table failed with profile %#v.\nWanted:\n%#v\nGot   :\n%#v\n", tc.profile, tc.want, got)
+		}
+	}
+}
+
+func TestTableWithTerminal(t *testing.T) {
+	row := []string{"Hello world!", "Hello ladies and gentlemen!"}
+
+	testCases := []struct {
+		term *terminal.Fake
+		want string
+	}{
+		{&terminal.Fake{Width: 30}, "Hello world! Hello ladies and \n             gentlemen!       "},
+		{&terminal.Fake{}, "Hello world! Hello ladies and gentlemen!"},
+	}
+
+	for _, tc := range testCases {
+		got := New().SetTerminal(tc.term).AddRows(row).String()
+		if got != tc.want {
+			t.Errorf("table failed with terminal %#v.\nWanted:\n%#v\nGot   :\n%#v\n", tc.term, tc.want, got)
+		}
+	}
+}
+
+func TestTableWithTabs(t *testing.T) {
+	got := New().SetGrid(&Grid{Columns: "|"}).AddRows([]string{"a\tb", "c"}, []string{"ab\tc", "d"}).String()
+	want := "a       b|c\nab      c|d"
+	if got != want {
+		t.Errorf("table failed with tabs.\nWanted:\n%#v\nGot   :\n%#v\n", want, got)
+	}
+}
@@ -0,0 +1,116 @@
+package text
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/pirmd/text/ansi"
+	"github.com/pirmd/text/diff"
+	"github.com/pirmd/text/table"
+	"github.com/pirmd/text/terminal"
+	"github.com/pirmd/text/visual"
+)
+
+// FuncMap provides a text/template FuncMap compatible mapping to use 'text'
+// functions within templates. It also includes ansi.FuncMap's functions.
+//
+// Functions' arguments are ordered so that the text to format comes last and
+// can be provided through a pipeline (like {{ .Description | Wrap 40 }}).
+// TermWidth gives access to the terminal's width (like {{ .Description |
+// Wrap TermWidth }}).
+func FuncMap() map[string]interface{} {
+	fm := ansi.FuncMap()
+
+	for name, fn := range map[string]interface{}{
+		"Wrap":        func(sz int, s string) string { return Wrap(s, sz) },
+		"LazyWrap":    func(sz int, s string) string { return LazyWrap(s, sz) },
+		"OptimalWrap": func(sz int, s string) string { return OptimalWrap(s, sz) },
+		"Tab":         func(tag, prefix string, sz int, s string) string { return Tab(s, tag, prefix, sz) },
+		"LazyTab":     func(tag, prefix string, sz int, s string) string { return LazyTab(s, tag, prefix, sz) },
+		"OptimalTab":  func(tag, prefix string, sz int, s string) string { return OptimalTab(s, tag, prefix, sz) },
+		"Reflow":      func(sz int, s string) string { return Reflow(s, sz) },
+		"Indent":      func(tag, prefix string, s string) string { return Indent(s, tag, prefix) },
+		"Justify":     func(sz int, s string) string { return Justify(s, sz) },
+		"Left":        func(sz int, s string) string { return Left(s, sz) },
+		"Right":       func(sz int, s string) string { return Right(s, sz) },
+		"Center":      func(sz int, s string) string { return Center(s, sz) },
+
+		"Width":     visual.Stringwidth,
+		"Truncate":  func(sz int, s string) string { return visual.Truncate(s, sz) },
+		"Repeat":    func(sz int, s string) string { return visual.Repeat(s, sz) },
+		"PadRight":  func(sz int, s string) string { return string(visual.PadRight([]byte(s), sz)) },
+		"PadLeft":   func(sz int, s string) string { return string(visual.PadLeft([]byte(s), sz)) },
+		"PadCenter": func(sz int, s string) string { return string(visual.PadCenter([]byte(s), sz)) },
+		"TermWidth": TermWidth,
+
+		"Columnize":       Columnize,
+		"Columns":         func(sz int, items []string) string { return Columns(items, sz) },
+		"ColumnsAcross":   func(sz int, items []string) string { return ColumnsAcross(items, sz) },
+		"Tabulate":        Tabulate,
+		"Table":           func(rows interface{}) (string, error) { return tableOf(rows, false) },
+		"TableWithHeader": func(rows interface{}) (string, error) { return tableOf(rows, true) },
+
+		"Diff": func(l, r string) string { return diff.Patience(l, r, diff.ByLines).PrintSideBySide(diff.WithColor) },
+	} {
+		fm[name] = fn
+	}
+
+	return fm
+}
+
+// TermWidth returns the width of the DefaultTerminal or
+// table.DefaultMaxWidth if it cannot be determined.
+func TermWidth() int {
+	return terminal.Width(DefaultTerminal, table.DefaultMaxWidth)
+}
+
+// tableOf draws a table from a slice of rows. Rows are either slices (or
+// arrays) whose elements are the table's cells, structs whose exported fields
+// are the table's cells or any other value that is drawn as a single cell.
+// If withHeader is true, first row is used as the table's header.
+func tableOf(rows interface{}, withHeader bool) (string, error) {
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("cannot build a table from '%T'", rows)
+	}
+
+	tab := table.New().SetMaxWidth(TermWidth())
+	for i := 0; i < v.Len(); i++ {
+		row := cellsOf(v.Index(i))
+		if i == 0 && withHeader {
+			tab.SetHeader(row...)
+			continue
+		}
+		tab.AddRows(row)
+	}
+
+	return tab.String(), nil
+}
+
+func cellsOf(v reflect.Value) (cells []string) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return []string{""}
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			cells = append(cells, fmt.Sprint(v.Index(i).Interface()))
+		}
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				cells = append(cells, fmt.Sprint(v.Field(i).Interface()))
+			}
+		}
+
+	default:
+		cells = []string{fmt.Sprint(v.Interface())}
+	}
+
+	return
+}
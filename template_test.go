@@ -0,0 +1,46 @@
+package text
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestFuncMap(t *testing.T) {
+	type item struct {
+		Name  string
+		Value int
+		note  string
+	}
+
+	testCases := []struct {
+		tmpl string
+		data interface{}
+		want string
+	}{
+		{`{{ . | Wrap 10 }}`, "This is a long sentence", "This is a\nlong\nsentence"},
+		{`{{ . | Tab "- " "  " 12 }}`, "This is a long sentence", "- This is a\n  long\n  sentence"},
+		{`{{ . | Truncate 4 }}|{{ . | PadRight 10 }}|{{ Width . }}`, "Bonjour", "Bonj|Bonjour   |7"},
+		{`{{ . | Bold | Center 9 }}`, "Bonjour", " \x1b[1mBonjour\x1b[22m "},
+		{`{{ TableWithHeader . }}`, [][]string{{"A", "B"}, {"val1", "val2"}}, "A    B   \nval1 val2"},
+		{`{{ Table . }}`, []item{{"one", 1, ""}, {"two", 2, ""}}, "one 1\ntwo 2"},
+		{`{{ Table . }}`, []interface{}{[]int{1, 2}, "three"}, "1     2\nthree  "},
+	}
+
+	for _, tc := range testCases {
+		tmpl, err := template.New("test").Funcs(FuncMap()).Parse(tc.tmpl)
+		if err != nil {
+			t.Fatalf("Fail to parse template %#v: %v", tc.tmpl, err)
+		}
+
+		var got strings.Builder
+		if err := tmpl.Execute(&got, tc.data); err != nil {
+			t.Errorf("Fail to execute template %#v: %v", tc.tmpl, err)
+			continue
+		}
+
+		if got.String() != tc.want {
+			t.Errorf("Template %#v failed for %#v.\nWanted:\n%#v\nGot   :\n%#v\n", tc.tmpl, tc.data, tc.want, got.String())
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package terminal
+
+import (
+	"github.com/pirmd/text/ansi"
+)
+
+// Fake is a Terminal whose characteristics are given by its fields. It is
+// typically used in tests or to force the formatting of an output.
+type Fake struct {
+	// Width and Height are the Terminal's size, 0 meaning unknown.
+	Width, Height int
+	// TTY reports whether the Terminal is interactive.
+	TTY bool
+	// ColorProfile is the Terminal's support of ANSI Graphic Renditions.
+	ColorProfile ansi.Profile
+}
+
+// IsTerminal reports whether the Fake terminal is interactive.
+func (f *Fake) IsTerminal() bool {
+	return f.TTY
+}
+
+// Size returns the Fake terminal's size.
+func (f *Fake) Size() (width, height int, ok bool) {
+	return f.Width, f.Height, f.Width > 0
+}
+
+// Profile returns the Fake terminal's support of ANSI Graphic Renditions.
+func (f *Fake) Profile() ansi.Profile {
+	return f.ColorProfile
+}
@@ -0,0 +1,102 @@
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/pirmd/text/visual"
+)
+
+const (
+	// defaultWidth is the width used to draw a Frame if the Terminal's width
+	// cannot be determined.
+	defaultWidth = 80
+)
+
+// Frame is a content displayed on a Terminal that can be redrawn, notably
+// when the terminal is resized. The content is produced by a render function
+// that lays it out for a given width, like:
+//
+//	tab := table.New().AddRows(rows...)
+//	func(width int) string { return tab.SetMaxWidth(width).String() }
+//
+// or, for a document:
+//
+//	func(width int) string { return doc.Text(d, width) }
+type Frame struct {
+	out    io.Writer
+	term   Terminal
+	render func(width int) string
+
+	mu   sync.Mutex
+	last string
+}
+
+// NewFrame creates a new Frame that is drawn to out, attached to term, using
+// render to lay out its content.
+func NewFrame(out io.Writer, term Terminal, render func(width int) string) *Frame {
+	return &Frame{
+		out:    out,
+		term:   term,
+		render: render,
+	}
+}
+
+// Draw lays out the Frame's content at the Terminal's current width and
+// writes it to the Frame's output. If the Terminal is interactive, the
+// previously drawn content is erased beforehand so that it is replaced by
+// the new one.
+func (f *Frame) Draw() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	width := Width(f.term, defaultWidth)
+
+	var erase string
+	if f.term.IsTerminal() {
+		if n := rows(f.last, width); n > 0 {
+			// move cursor to the beginning of the first line of the last
+			// content then erase up to the end of the screen.
+			erase = fmt.Sprintf("\x1b[%dF\x1b[J", n)
+		}
+	}
+
+	content := f.render(width)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	if _, err := io.WriteString(f.out, erase+content); err != nil {
+		return err
+	}
+
+	f.last = content
+	return nil
+}
+
+// Watch redraws the Frame each time the Terminal is resized, until the
+// returned stop function is called. See NotifyResize.
+func (f *Frame) Watch() (stop func()) {
+	return OnResize(f.term, func(int) { _ = f.Draw() })
+}
+
+// rows returns the number of rows used to display s on a terminal of the
+// given width, taking into account that lines longer than width are wrapped
+// by the terminal.
+func rows(s string, width int) (n int) {
+	if s == "" {
+		return 0
+	}
+
+	for _, l := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		w := visual.Stringwidth(l)
+		if w <= width || width <= 0 {
+			n++
+			continue
+		}
+		n += (w + width - 1) / width
+	}
+	return
+}
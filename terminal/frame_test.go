@@ -0,0 +1,60 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFrame(t *testing.T) {
+	render := func(width int) string {
+		return strings.Repeat("=", width) + "\n" + strings.Repeat("-", width/2)
+	}
+
+	testCases := []struct {
+		tty   bool
+		width int
+		want  string
+	}{
+		{true, 10, "==========\n-----\n"},
+		{true, 6, "\x1b[3F\x1b[J======\n---\n"},
+		{true, 12, "\x1b[2F\x1b[J============\n------\n"},
+		{false, 10, "==========\n-----\n"},
+	}
+
+	term := &Fake{TTY: true}
+	var out strings.Builder
+	f := NewFrame(&out, term, render)
+
+	for _, tc := range testCases {
+		term.TTY, term.Width = tc.tty, tc.width
+		out.Reset()
+
+		if err := f.Draw(); err != nil {
+			t.Fatalf("Fail to draw frame: %v", err)
+		}
+
+		if got := out.String(); got != tc.want {
+			t.Errorf("Fail to draw frame at width %d.\nWant: %q\nGot : %q", tc.width, tc.want, got)
+		}
+	}
+}
+
+func TestRows(t *testing.T) {
+	testCases := []struct {
+		in    string
+		width int
+		want  int
+	}{
+		{"", 10, 0},
+		{"\n", 10, 1},
+		{"one\ntwo\n", 10, 2},
+		{"one\ntwo", 10, 2},
+		{"\x1b[1m0123456789\x1b[0m\n日本語\n", 4, 5},
+	}
+
+	for _, tc := range testCases {
+		if got := rows(tc.in, tc.width); got != tc.want {
+			t.Errorf("Fail to count rows of %q at width %d.\nWant: %d\nGot : %d", tc.in, tc.width, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package terminal
+
+import (
+	"sync"
+)
+
+// NotifyResize returns a channel that receives the Terminal's new width each
+// time the terminal is resized, as well as a function to stop watching for
+// resizes, that closes the channel.
+//
+// Only the latest width is kept if the channel's receiver is slower than the
+// resizes. Resizes whose width cannot be determined are ignored.
+//
+// Resizes are detected by watching the SIGWINCH signal, which is supported
+// on Unix-like platforms: on other platforms (like Windows), the channel
+// never receives.
+func NotifyResize(t Terminal) (<-chan int, func()) {
+	sig, stopWatching := watchResize()
+
+	widths := make(chan int, 1)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(widths)
+
+		for {
+			select {
+			case <-done:
+				return
+
+			case <-sig:
+				w, _, ok := t.Size()
+				if !ok {
+					continue
+				}
+
+				// drop any pending width that has not been received
+				// yet.
+				select {
+				case <-widths:
+				default:
+				}
+				widths <- w
+			}
+		}
+	}()
+
+	var once sync.Once
+	return widths, func() {
+		once.Do(func() {
+			stopWatching()
+			close(done)
+		})
+	}
+}
+
+// OnResize calls fn with the Terminal's new width each time the terminal is
+// resized, until the returned stop function is called. See NotifyResize.
+func OnResize(t Terminal, fn func(width int)) (stop func()) {
+	widths, stop := NotifyResize(t)
+
+	go func() {
+		for w := range widths {
+			fn(w)
+		}
+	}()
+
+	return stop
+}
@@ -0,0 +1,14 @@
+//go:build windows || plan9 || js
+// +build windows plan9 js
+
+package terminal
+
+import (
+	"os"
+)
+
+// watchResize returns a channel that never receives as resizes cannot be
+// detected on this platform.
+func watchResize() (<-chan os.Signal, func()) {
+	return nil, func() {}
+}
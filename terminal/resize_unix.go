@@ -0,0 +1,18 @@
+//go:build !windows && !plan9 && !js
+// +build !windows,!plan9,!js
+
+package terminal
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchResize returns a channel that receives a signal each time the
+// terminal is resized and a function to stop watching for resizes.
+func watchResize() (<-chan os.Signal, func()) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGWINCH)
+	return sig, func() { signal.Stop(sig) }
+}
@@ -0,0 +1,32 @@
+//go:build !windows && !plan9 && !js
+// +build !windows,!plan9,!js
+
+package terminal
+
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyResize(t *testing.T) {
+	widths, stop := NotifyResize(&Fake{Width: 42})
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGWINCH); err != nil {
+		t.Fatalf("Fail to send SIGWINCH: %v", err)
+	}
+
+	select {
+	case w := <-widths:
+		if w != 42 {
+			t.Errorf("Fail to notify resize.\nWant: 42\nGot : %d", w)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Fail to notify resize: no width received")
+	}
+
+	stop()
+	if _, ok := <-widths; ok {
+		t.Errorf("Fail to stop notifying resize: channel is not closed")
+	}
+}
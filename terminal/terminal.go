@@ -0,0 +1,118 @@
+// Package terminal describes the terminal (or lack thereof) text is written
+// to: its size, its support of ANSI escape sequences and whether it is an
+// interactive terminal at all.
+//
+// A Terminal can be obtained for any io.Writer so that text written to the
+// standard error, to a file or to a buffer is formatted accordingly. Fake
+// offers a Terminal whose characteristics are fully controlled, notably for
+// tests.
+package terminal
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"golang.org/x/term"
+
+	"github.com/pirmd/text/ansi"
+)
+
+// Terminal describes the terminal an output is attached to.
+type Terminal interface {
+	// IsTerminal reports whether the output is an interactive terminal.
+	IsTerminal() bool
+
+	// Size returns the terminal's width and height in cells. ok is false if
+	// the width cannot be determined, height being 0 if unknown.
+	Size() (width, height int, ok bool)
+
+	// Profile returns the level of support of ANSI Graphic Renditions of the
+	// terminal. Text can be adapted to it using ansi.NewWriter.
+	Profile() ansi.Profile
+}
+
+// fder is implemented by outputs that are backed by a file descriptor, like
+// *os.File.
+type fder interface {
+	Fd() uintptr
+}
+
+// fileTerminal is a Terminal attached to a file descriptor (or to any
+// io.Writer, fd being then invalid).
+type fileTerminal struct {
+	fd      int
+	isTerm  bool
+	getenv  func(string) string
+	getsize func(fd int) (width, height int, err error)
+}
+
+// New returns the Terminal that out is attached to.
+//
+// out is considered as a terminal if it is backed by a file descriptor (like
+// *os.File) that refers to a terminal. Its size is the terminal's actual
+// size, COLUMNS and LINES environment variables giving the size of outputs
+// that are not terminals (or whose size cannot be determined). Profile is
+// guessed the way ansi.DetectProfile does.
+func New(out io.Writer) Terminal {
+	return newTerminal(out, os.Getenv)
+}
+
+// Stdout returns the Terminal attached to the standard output.
+func Stdout() Terminal {
+	return New(os.Stdout)
+}
+
+// Stderr returns the Terminal attached to the standard error.
+func Stderr() Terminal {
+	return New(os.Stderr)
+}
+
+func newTerminal(out io.Writer, getenv func(string) string) *fileTerminal {
+	t := &fileTerminal{fd: -1, getenv: getenv, getsize: term.GetSize}
+
+	if f, ok := out.(fder); ok {
+		t.fd = int(f.Fd())
+		t.isTerm = term.IsTerminal(t.fd)
+	}
+
+	return t
+}
+
+func (t *fileTerminal) IsTerminal() bool {
+	return t.isTerm
+}
+
+func (t *fileTerminal) Size() (width, height int, ok bool) {
+	if t.isTerm {
+		if w, h, err := t.getsize(t.fd); err == nil && w > 0 {
+			return w, h, true
+		}
+	}
+
+	width, height = t.envInt("COLUMNS"), t.envInt("LINES")
+	return width, height, width > 0
+}
+
+func (t *fileTerminal) Profile() ansi.Profile {
+	return ansi.GuessProfile(t.isTerm, t.getenv)
+}
+
+// envInt returns the value of an environment variable holding a positive
+// integer or 0 if it is not set or invalid.
+func (t *fileTerminal) envInt(key string) int {
+	n, err := strconv.Atoi(t.getenv(key))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// Width returns the width of a Terminal or fallback if it cannot be
+// determined.
+func Width(t Terminal, fallback int) int {
+	if w, _, ok := t.Size(); ok {
+		return w
+	}
+	return fallback
+}
@@ -0,0 +1,94 @@
+package terminal
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/pirmd/text/ansi"
+)
+
+func TestNew(t *testing.T) {
+	devnull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("cannot open %s: %v", os.DevNull, err)
+	}
+	defer devnull.Close()
+
+	testCases := []struct {
+		out         io.Writer
+		env         map[string]string
+		wantWidth   int
+		wantHeight  int
+		wantOK      bool
+		wantProfile ansi.Profile
+	}{
+		{new(bytes.Buffer), nil, 0, 0, false, ansi.Plain},
+		{new(bytes.Buffer), map[string]string{"COLUMNS": "132", "LINES": "43"}, 132, 43, true, ansi.Plain},
+		{new(bytes.Buffer), map[string]string{"COLUMNS": "wide"}, 0, 0, false, ansi.Plain},
+		{new(bytes.Buffer), map[string]string{"TERM": "xterm-256color", "CLICOLOR_FORCE": "1"}, 0, 0, false, ansi.Color256},
+		{devnull, map[string]string{"COLUMNS": "100", "TERM": "xterm"}, 100, 0, true, ansi.Plain},
+	}
+
+	for _, tc := range testCases {
+		term := newTerminal(tc.out, func(k string) string { return tc.env[k] })
+
+		if term.IsTerminal() {
+			t.Errorf("Fail to detect that %T is not a terminal", tc.out)
+		}
+
+		if w, h, ok := term.Size(); w != tc.wantWidth || h != tc.wantHeight || ok != tc.wantOK {
+			t.Errorf("Fail to get size with env %v.\nWant: %d, %d, %v\nGot : %d, %d, %v", tc.env, tc.wantWidth, tc.wantHeight, tc.wantOK, w, h, ok)
+		}
+
+		if p := term.Profile(); p != tc.wantProfile {
+			t.Errorf("Fail to get profile with env %v.\nWant: %v\nGot : %v", tc.env, tc.wantProfile, p)
+		}
+	}
+}
+
+func TestSize(t *testing.T) {
+	env := map[string]string{"COLUMNS": "132", "LINES": "43"}
+
+	testCases := []struct {
+		isTerm     bool
+		getsize    func(int) (int, int, error)
+		wantWidth  int
+		wantHeight int
+	}{
+		{true, func(int) (int, int, error) { return 100, 30, nil }, 100, 30},
+		{true, func(int) (int, int, error) { return 0, 0, errors.New("no size") }, 132, 43},
+		{false, func(int) (int, int, error) { return 100, 30, nil }, 132, 43},
+	}
+
+	for _, tc := range testCases {
+		term := &fileTerminal{
+			isTerm:  tc.isTerm,
+			getenv:  func(k string) string { return env[k] },
+			getsize: tc.getsize,
+		}
+
+		if w, h, ok := term.Size(); w != tc.wantWidth || h != tc.wantHeight || !ok {
+			t.Errorf("Fail to get size of terminal (isTerm: %v).\nWant: %d, %d, true\nGot : %d, %d, %v", tc.isTerm, tc.wantWidth, tc.wantHeight, w, h, ok)
+		}
+	}
+}
+
+func TestWidth(t *testing.T) {
+	testCases := []struct {
+		term Terminal
+		want int
+	}{
+		{&Fake{Width: 120}, 120},
+		{&Fake{}, 80},
+		{newTerminal(new(bytes.Buffer), func(string) string { return "" }), 80},
+	}
+
+	for _, tc := range testCases {
+		if got := Width(tc.term, 80); got != tc.want {
+			t.Errorf("Fail to get width of %#v.\nWant: %d\nGot : %d", tc.term, tc.want, got)
+		}
+	}
+}
@@ -1,15 +1,20 @@
 package text
 
 import (
-	"os"
 	"strings"
 
-	"golang.org/x/term"
-
 	"github.com/pirmd/text/table"
+	"github.com/pirmd/text/terminal"
 	"github.com/pirmd/text/visual"
 )
 
+var (
+	// DefaultTerminal is the Terminal whose width is used by functions that
+	// adapt their output to the terminal's width (like Columnize or
+	// TermWidth). Default to the terminal attached to the standard output.
+	DefaultTerminal terminal.Terminal = terminal.Stdout()
+)
+
 // Indent inserts a name/bullet/number at the beginning of the string, then
 // indents it (add prefix at the beginning and before any new line).
 //
@@ -48,6 +53,20 @@ func LazyWrap(s string, sz int) string {
 	return wrap(s, visual.NewLazyCutter(sz))
 }
 
+// OptimalWrap wraps a text by ensuring that each of its line's "visual"
+// length is lower or equal to the provided limit. Unlike Wrap that fills each
+// line as much as possible, OptimalWrap considers each paragraph as a whole
+// and splits it so that lines' lengths are as even as possible (minimum
+// raggedness).
+//
+// If a "word" is encountered that is longer than the limit, it is split in
+// chunks.
+func OptimalWrap(s string, sz int) string {
+	cutr := visual.NewCutter(sz)
+	cutr.Optimal = true
+	return wrap(s, cutr)
+}
+
 // Tab wraps and indents the given text.
 //
 // Tab will additionally add the given tag in front of the first line. Tag is
@@ -97,10 +116,48 @@ func LazyTab(s string, tag, prefix string, sz int) string {
 	return indent(r, tag, prefix)
 }
 
+// OptimalTab wraps and indents the given text.
+//
+// OptimalTab operates like Tab but relies on OptimalWrap to obtain lines of
+// even lengths.
+func OptimalTab(s string, tag, prefix string, sz int) string {
+	lT, lP := visual.Stringwidth(tag), visual.Stringwidth(prefix)
+
+	var r string
+	switch {
+	case lT > lP:
+		prefix = string(visual.PadRight([]byte(prefix), lT))
+		r = OptimalWrap(s, sz-lT)
+	case lT < lP:
+		tag = visual.Truncate(prefix, lP-lT) + tag
+		r = OptimalWrap(s, sz-lP)
+	default:
+		r = OptimalWrap(s, sz-lP)
+	}
+
+	return indent(r, tag, prefix)
+}
+
 // Justify wraps a text to the given maximum size and makes sure that returned
-// lines are of exact provided size by wrapping and padding them as needed.
+// lines are of exact provided size by distributing spaces between words.
+// Paragraphs' last lines are aligned on the left and padded with spaces.
 func Justify(s string, sz int) string {
-	return Left(s, sz)
+	if len(s) == 0 {
+		return strings.Repeat(" ", sz)
+	}
+
+	ws := visual.Cut(s, sz)
+	for i, l := range ws {
+		isLastLine := i == len(ws)-1 || strings.ContainsRune(l, '\n')
+
+		l = string(visual.TrimLeadingSpace([]byte(l)))
+		if isLastLine {
+			ws[i] = string(visual.PadRight([]byte(l), sz))
+		} else {
+			ws[i] = string(visual.Justify([]byte(l), sz))
+		}
+	}
+	return strings.Join(ws, "\n")
 }
 
 // Left aligns text on the left and pads it with spaces to reach the given
@@ -149,13 +206,7 @@ func Center(s string, sz int) string {
 
 // Columnize organizes supplied strings in a side-by-side fashion.
 func Columnize(columns ...string) string {
-	tab := table.New()
-
-	if fd := int(os.Stdout.Fd()); term.IsTerminal(fd) {
-		if w, _, err := term.GetSize(fd); err == nil {
-			tab.SetMaxWidth(w)
-		}
-	}
+	tab := table.New().SetTerminal(DefaultTerminal)
 
 	col := make([][]string, len(columns))
 	for i := range columns {
@@ -168,13 +219,7 @@ func Columnize(columns ...string) string {
 // Rowwise organizes supplied strings in a table-like fashion. Each string is
 // displayed as a table row, each '\t' delimiting a column.
 func Rowwise(rows ...string) string {
-	tab := table.New()
-
-	if fd := int(os.Stdout.Fd()); term.IsTerminal(fd) {
-		if w, _, err := term.GetSize(fd); err == nil {
-			tab.SetMaxWidth(w)
-		}
-	}
+	tab := table.New().SetTerminal(DefaultTerminal)
 
 	return tab.AddTabbedRows(rows...).String()
 }
@@ -182,13 +227,7 @@ func Rowwise(rows ...string) string {
 // Tabulate organizes supplied string in a table-like fashion. Each '\t\n'
 // delimiting a table's row and each '\t' a column.
 func Tabulate(tabbedtext string) string {
-	tab := table.New()
-
-	if fd := int(os.Stdout.Fd()); term.IsTerminal(fd) {
-		if w, _, err := term.GetSize(fd); err == nil {
-			tab.SetMaxWidth(w)
-		}
-	}
+	tab := table.New().SetTerminal(DefaultTerminal)
 
 	return tab.AddTabbedText(tabbedtext).String()
 }
@@ -214,18 +253,27 @@ func indent(s string, firstPrefix, prefix string) string {
 func wrap(s string, cutr *visual.Cutter) string {
 	out := new(strings.Builder)
 	in := visual.TrimSpace([]byte(s))
-	line, cut := cutr.Split(in)
 
-	for line != nil {
+	var lines [][]byte
+	var cut []byte
+	if cutr.Optimal {
+		// optimal wrapping lays out each paragraph as a whole.
+		lines, cut = cutr.Lines(in)
+	} else {
+		var line []byte
+		for line, cut = cutr.Split(in); line != nil; line, cut = cutr.Split(in) {
+			lines = append(lines, line)
+			in = visual.TrimSpace(cut)
+		}
+	}
+
+	for _, line := range lines {
 		if out.Len() > 0 {
 			out.WriteByte('\n')
 		}
 
 		l := visual.TrimSpace(line)
 		out.Write(l)
-
-		in = visual.TrimSpace(cut)
-		line, cut = cutr.Split(in)
 	}
 
 	if len(cut) > 0 {
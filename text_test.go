@@ -2,6 +2,8 @@ package text
 
 import (
 	"testing"
+
+	"github.com/pirmd/text/terminal"
 )
 
 func TestWrap(t *testing.T) {
@@ -69,6 +71,28 @@ func TestLazyWrap(t *testing.T) {
 	}
 }
 
+func TestOptimalWrap(t *testing.T) {
+	testCases := []struct {
+		in  string
+		sz  int
+		out string
+	}{
+		{"Coucou", 10, "Coucou"},
+		{"Coucou\n", 8, "Coucou\n"},
+		{"aaa bb cc ddddd", 6, "aaa\nbb cc\nddddd"},
+		{"aaa \x1b[34mbb cc\x1b[0m ddddd", 6, "aaa\x1b[34m\nbb cc\x1b[0m\nddddd"},
+		{"Supercalifragilistic word", 10, "Supercalif\nragilistic\nword"},
+		{"description: This edition contains Alice's Adventures in Wonderland. Tweedledum and Tweedledee, the Mad Hatter, the Cheshire Cat, the Red Queen and the White Rabbit all make their appearances, and are now familiar figures in writing, conversation and idiom.\nauthor: Lewis Caroll", 58, "description: This edition contains Alice's Adventures in\nWonderland. Tweedledum and Tweedledee, the Mad Hatter,\nthe Cheshire Cat, the Red Queen and the White Rabbit all\nmake their appearances, and are now familiar figures in\nwriting, conversation and idiom.\nauthor: Lewis Caroll"},
+	}
+
+	for _, tc := range testCases {
+		got := OptimalWrap(tc.in, tc.sz)
+		if got != tc.out {
+			t.Errorf("Wrap failed for %#v.\nWanted:\n%#v\nGot   :\n%#v\n", tc.in, tc.out, got)
+		}
+	}
+}
+
 func TestTab(t *testing.T) {
 	testCases := []struct {
 		inTxt string
@@ -213,9 +237,14 @@ func TestJustify(t *testing.T) {
 		{"Coucou", 10, "Coucou    "},
 		{"Coucou", 6, "Coucou"},
 		{"Coucou ", 6, "Coucou\n      "},
-		{"This is a long sentence", 10, "This is a \nlong      \nsentence  "},
-		{"This \x1b[34mis\x1b[0m a long sentence", 10, "This \x1b[34mis\x1b[0m a \nlong      \nsentence  "},
-		{"This \x1b[34mis a long sentence\x1b[0m", 10, "This \x1b[34mis a \nlong      \nsentence\x1b[0m  "},
+		{"This is a long sentence", 10, "This  is a\nlong      \nsentence  "},
+		{"This \x1b[34mis\x1b[0m a long sentence", 10, "This  \x1b[34mis\x1b[0m a\nlong      \nsentence  "},
+		{"This \x1b[34mis a long sentence\x1b[0m", 10, "This  \x1b[34mis a\nlong      \nsentence\x1b[0m  "},
+		{"This is a short paragraph.\nAnd another one.", 12, "This   is  a\nshort       \nparagraph.  \nAnd  another\none.        "},
+		{"a\tb c", 6, "a     \nb c   "},
+		{"a\tb", 4, "a   \nb   "},
+		{"abc", 1, "a\nb\nc"},
+		{"日本語", 2, "日\n本\n語"},
 	}
 
 	for _, tc := range testCases {
@@ -305,6 +334,20 @@ func TestColumnize(t *testing.T) {
 	}
 }
 
+func TestColumnizeWithTerminal(t *testing.T) {
+	defer func(term terminal.Terminal) { DefaultTerminal = term }(DefaultTerminal)
+	DefaultTerminal = &terminal.Fake{Width: 20}
+
+	want := "Hello     Hello    \nworld!    ladies   \n          and      \n          gentlemen\n          !        "
+	if got := Columnize("Hello world!", "Hello ladies and gentlemen!"); got != want {
+		t.Errorf("Columnize failed with terminal %#v.\nWant:\n%#v\nGot :\n%#v\n", DefaultTerminal, want, got)
+	}
+
+	if got := TermWidth(); got != 20 {
+		t.Errorf("TermWidth failed with terminal %#v.\nWant: 20\nGot : %d", DefaultTerminal, got)
+	}
+}
+
 func TestRowwise(t *testing.T) {
 	testCases := []struct {
 		in  []string
@@ -2,8 +2,6 @@ package txtwriter
 
 import (
 	"bytes"
-
-	"github.com/pirmd/text/visual"
 )
 
 type alignment int
@@ -12,10 +10,11 @@ const (
 	alignLeft alignment = iota
 	alignRight
 	alignCenter
+	alignJustify
 )
 
 func (a alignment) String() string {
-	return [...]string{"Left", "Right", "Center"}[a]
+	return [...]string{"Left", "Right", "Center", "Justify"}[a]
 }
 
 // AlignLeft will align text to the left (default).
@@ -36,6 +35,14 @@ func (w *Writer) AlignCenter() *Writer {
 	return w
 }
 
+// AlignJustify will justify text, that is to say will distribute spaces
+// between words so that lines reach Writer's maximum width. The last line of a
+// paragraph is aligned to the left.
+func (w *Writer) AlignJustify() *Writer {
+	w.alignment = alignJustify
+	return w
+}
+
 // PadLeft will ad spaces  at the end of lines up-to reaching Writer's maximum
 // visual width.
 func (w *Writer) PadLeft() *Writer {
@@ -52,7 +59,7 @@ func (w *Writer) PadLeft() *Writer {
 // leading or trailing spaces might appear with the current ANSI formatting
 // (like strike-out or in inverse color)
 func (w *Writer) alignLine(p []byte) (int, error) {
-	if freespace := w.width() - visual.Width(p); freespace > 0 {
+	if freespace := w.width() - w.profile.Width(p); freespace > 0 {
 		switch w.alignment {
 		case alignRight:
 			return w.out.Write(bytes.Repeat([]byte{' '}, freespace))
@@ -73,9 +80,9 @@ func (w *Writer) alignLine(p []byte) (int, error) {
 // leading or trailing spaces might appear with the current ANSI formatting
 // (like strike-out or in inverse color)
 func (w *Writer) padLine(p []byte) (int, error) {
-	if freespace := w.width() - visual.Width(p); freespace > 0 {
+	if freespace := w.width() - w.profile.Width(p); freespace > 0 {
 		switch w.alignment {
-		case alignLeft:
+		case alignLeft, alignJustify:
 			return w.out.Write(bytes.Repeat([]byte{' '}, freespace))
 
 		case alignCenter:
@@ -169,3 +169,69 @@ func TestAlignCenterPadWithSpaces(t *testing.T) {
 		}
 	}
 }
+
+func TestAlignJustify(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{
+			in:   "Hello world!",
+			want: "Hello world!",
+		},
+		{
+			in:   "Hello!",
+			want: "Hello!",
+		},
+		{
+			in:   "Hello my dear ladies and gentlemen!",
+			want: "Hello     my\ndear  ladies\nand\ngentlemen!",
+		},
+		{
+			in:   "Hello my dear\nladies and gentlemen!\n",
+			want: "Hello     my\ndear\nladies   and\ngentlemen!\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		got := new(strings.Builder)
+
+		tstwriter := New(got).SetMaxWidth(12).AlignJustify()
+
+		tstwriter.Write([]byte(tc.in))
+		tstwriter.Flush()
+
+		if got.String() != tc.want {
+			t.Errorf("Fail to write '%s'.\nWant:\n%#v\n\nGot :\n%#v", tc.in, tc.want, got.String())
+		}
+	}
+}
+
+func TestAlignJustifyPadWithSpaces(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{
+			in:   "Hello!",
+			want: "Hello!      ",
+		},
+		{
+			in:   "Hello my dear ladies and gentlemen!",
+			want: "Hello     my\ndear  ladies\nand         \ngentlemen!  ",
+		},
+	}
+
+	for _, tc := range testCases {
+		got := new(strings.Builder)
+
+		tstwriter := New(got).SetMaxWidth(12).AlignJustify().PadLeft()
+
+		tstwriter.Write([]byte(tc.in))
+		tstwriter.Flush()
+
+		if got.String() != tc.want {
+			t.Errorf("Fail to write '%s'.\nWant:\n%#v\n\nGot :\n%#v", tc.in, tc.want, got.String())
+		}
+	}
+}
@@ -14,6 +14,13 @@ func (w *Writer) SetPrefix(prefixes ...string) *Writer {
 	return w
 }
 
+// TrimBlankPrefix sets Writer to omit the trailing spaces of prefixes on blank
+// lines, so that they do not end by invisible spaces.
+func (w *Writer) TrimBlankPrefix() *Writer {
+	w.trimBlank = true
+	return w
+}
+
 // ResetPrefix resets prefix's sequence.
 func (w *Writer) ResetPrefix() {
 	w.prefixIdx = -1
@@ -30,12 +37,19 @@ func (w *Writer) nextPrefix() {
 	if w.prefixIdx < len(w.prefixes)-1 {
 		w.prefixIdx++
 		w.prefix = []byte(w.prefixes[w.prefixIdx])
-		w.prefixWidth = visual.Width(w.prefix)
+		w.prefixWidth = w.profile.Width(w.prefix)
 	}
 }
 
-func (w *Writer) writePrefix() (int, error) {
-	n, err := w.out.Write(w.prefix)
+// writePrefix writes the current prefix, omitting its trailing spaces if
+// trim is true.
+func (w *Writer) writePrefix(trim bool) (int, error) {
+	prefix := w.prefix
+	if trim {
+		prefix = visual.TrimTrailingSpace(prefix)
+	}
+
+	n, err := w.out.Write(prefix)
 	if err != nil {
 		return n, err
 	}
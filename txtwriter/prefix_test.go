@@ -65,3 +65,75 @@ func TestPrefixMultiwrite(t *testing.T) {
 		}
 	}
 }
+
+func TestPrefixNoWrap(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{
+			in:   "func main() {\n\tfmt.Println()\n\n}",
+			want: "│ func main() {\n│         fmt.Println()\n│ \n│ }",
+		},
+		{
+			in:   "  a │ b  \n    │ c",
+			want: "│   a │ b  \n│     │ c",
+		},
+	}
+
+	for _, tc := range testCases {
+		got := new(strings.Builder)
+
+		tstwriter := New(got).SetMaxWidth(12).NoWrap().SetPrefix("│ ")
+
+		tstwriter.Write([]byte(tc.in))
+		tstwriter.Flush()
+
+		if got.String() != tc.want {
+			t.Errorf("Fail to write '%s'.\nWant:\n%#v\n\nGot :\n%#v", tc.in, tc.want, got.String())
+		}
+	}
+}
+
+func TestTrimBlankPrefix(t *testing.T) {
+	testCases := []struct {
+		in   string
+		trim bool
+		want string
+	}{
+		{
+			in:   "Hello\n\nworld",
+			want: "> Hello\n> \n> world",
+		},
+		{
+			in:   "Hello\n\nworld",
+			trim: true,
+			want: "> Hello\n>\n> world",
+		},
+		{
+			in:   "Hello\n",
+			want: "> Hello\n> ",
+		},
+		{
+			in:   "Hello\n",
+			trim: true,
+			want: "> Hello\n>",
+		},
+	}
+
+	for _, tc := range testCases {
+		got := new(strings.Builder)
+
+		tstwriter := New(got).SetMaxWidth(12).SetPrefix("> ")
+		if tc.trim {
+			tstwriter.TrimBlankPrefix()
+		}
+
+		tstwriter.Write([]byte(tc.in))
+		tstwriter.Flush()
+
+		if got.String() != tc.want {
+			t.Errorf("Fail to write '%s'.\nWant:\n%#v\n\nGot :\n%#v", tc.in, tc.want, got.String())
+		}
+	}
+}
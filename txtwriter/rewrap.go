@@ -0,0 +1,107 @@
+package txtwriter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// prefixChars lists the characters a line's prefix can be made of, like in
+// Go or shell comments ("// ", "# ") or in e-mail quotes ("> > ").
+const prefixChars = " \t/#>;%!|"
+
+// Rewrap rewraps a text whose lines carry a prefix, like comment blocks ("// ",
+// "# ") or quoted e-mails ("> > "), the way GNU par does: the text is split in
+// paragraphs made of consecutive lines sharing the same prefix, each paragraph
+// being wrapped to the maxwidth limit (prefix included) then prefixed again by
+// the prefix of the paragraph's first line.
+//
+// A run of prefix characters is only considered as a prefix if it is followed
+// by a space or if it is repeated on the following line, so that text like
+// paths ("/usr/local") is not mistaken for a prefix. Table rows (like
+// "| a | b |") are kept as is.
+// Lines whose only content is their prefix separate paragraphs and are kept
+// as is (trailing spaces trimmed).
+func Rewrap(s string, maxwidth int) string {
+	var out strings.Builder
+
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	for i := 0; i < len(lines); {
+		prefix := linePrefix(lines, i)
+
+		if strings.TrimSpace(lines[i][len(prefix):]) == "" || isTableRow(lines[i], prefix) {
+			out.WriteString(strings.TrimRight(lines[i], " \t"))
+			out.WriteByte('\n')
+			i++
+			continue
+		}
+
+		body := []string{strings.TrimSpace(lines[i][len(prefix):])}
+		j := i + 1
+		for ; j < len(lines); j++ {
+			text, ok := continuation(lines[j], prefix)
+			if !ok || strings.TrimSpace(text) == "" || isTableRow(lines[j], prefix) {
+				break
+			}
+			body = append(body, strings.TrimSpace(text))
+		}
+
+		w := New(&out).SetMaxWidth(maxwidth).SetPrefix(prefix)
+		w.Write([]byte(strings.Join(body, " ")))
+		w.Flush()
+		out.WriteByte('\n')
+
+		i = j
+	}
+
+	if strings.HasSuffix(s, "\n") {
+		return out.String()
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// linePrefix returns the prefix that starts lines[i]. A run of prefix
+// characters is a prefix if it is followed by a space (or ends the line) or
+// if the following line starts by the same run, otherwise only the line's
+// leading spaces are considered as a prefix.
+func linePrefix(lines []string, i int) string {
+	p := prefixRun(lines[i])
+	if p == lines[i] || strings.TrimRightFunc(p, unicode.IsSpace) != p {
+		return p
+	}
+
+	if i+1 < len(lines) && strings.TrimRight(prefixRun(lines[i+1]), " \t") == p {
+		return p
+	}
+
+	return lines[i][:len(lines[i])-len(strings.TrimLeft(lines[i], " \t"))]
+}
+
+// continuation returns the text of a line that follows a paragraph's line
+// whose prefix is given. ok is false if the line does not start by the same
+// prefix or if the prefix is followed by another one (like a nested quote),
+// prefix characters that are not followed by a space (like in "/usr/local")
+// being part of the text.
+func continuation(line, prefix string) (text string, ok bool) {
+	p := strings.TrimRight(prefix, " \t")
+	if !strings.HasPrefix(line, p) {
+		return "", false
+	}
+
+	text = strings.TrimLeft(line[len(p):], " \t")
+	if run := prefixRun(text); run != "" && (run == text || strings.TrimRightFunc(run, unicode.IsSpace) != run) {
+		return "", false
+	}
+
+	return text, true
+}
+
+// prefixRun returns the run of prefix characters that starts a line.
+func prefixRun(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, prefixChars))]
+}
+
+// isTableRow reports whether a line whose prefix is given is a table's row
+// (like "| a | b |").
+func isTableRow(line, prefix string) bool {
+	return strings.HasSuffix(strings.TrimSpace(prefix), "|") && strings.HasSuffix(strings.TrimRight(line, " \t"), "|")
+}
@@ -0,0 +1,95 @@
+package txtwriter
+
+import (
+	"testing"
+)
+
+func TestRewrap(t *testing.T) {
+	testCases := []struct {
+		in   string
+		sz   int
+		want string
+	}{
+		{
+			in:   "Hello world!",
+			sz:   12,
+			want: "Hello world!",
+		},
+		{
+			in:   "// Hello ladies\n// and gentlemen!\n",
+			sz:   20,
+			want: "// Hello ladies and\n// gentlemen!\n",
+		},
+		{
+			in:   "\t// Hello ladies and gentlemen!\n\t//\n\t// Bye.",
+			sz:   25,
+			want: "\t// Hello ladies\n\t// and gentlemen!\n\t//\n\t// Bye.",
+		},
+		{
+			in:   "# Hello\n#   ladies and gentlemen!",
+			sz:   20,
+			want: "# Hello ladies and\n# gentlemen!",
+		},
+		{
+			in:   "> > Hello ladies and gentlemen!\n> > Bye.\n>\n> Hello world!",
+			sz:   14,
+			want: "> > Hello\n> > ladies and\n> > gentlemen!\n> > Bye.\n>\n> Hello world!",
+		},
+		{
+			in:   "// Hello ladies and gentlemen!\n# Hello ladies and gentlemen!",
+			sz:   20,
+			want: "// Hello ladies and\n// gentlemen!\n# Hello ladies and\n# gentlemen!",
+		},
+		{
+			in:   "/usr/local holds the programs that are installed by hand\nby the administrator",
+			sz:   30,
+			want: "/usr/local holds the programs\nthat are installed by hand by\nthe administrator",
+		},
+		{
+			in:   "| a | b |\n| c | d |",
+			sz:   30,
+			want: "| a | b |\n| c | d |",
+		},
+		{
+			in:   "// first line of comment\n//second",
+			sz:   30,
+			want: "// first line of comment\n// second",
+		},
+		{
+			in:   "//first line of comment\n// second",
+			sz:   30,
+			want: "//first line of comment second",
+		},
+		{
+			in:   "// See /usr/local/\n// or /opt/",
+			sz:   30,
+			want: "// See /usr/local/ or /opt/",
+		},
+		{
+			in:   "// Programs are installed in\n// /usr/local by the administrator",
+			sz:   30,
+			want: "// Programs are installed in\n// /usr/local by the\n// administrator",
+		},
+		{
+			in:   "# Topics are tagged with\n# #hashtags",
+			sz:   40,
+			want: "# Topics are tagged with #hashtags",
+		},
+		{
+			in:   "Programs are installed in\n/usr/local by the administrator",
+			sz:   40,
+			want: "Programs are installed in /usr/local by\nthe administrator",
+		},
+		{
+			in:   "> Hello ladies and\n> > gentlemen!",
+			sz:   30,
+			want: "> Hello ladies and\n> > gentlemen!",
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := Rewrap(tc.in, tc.sz); got != tc.want {
+			t.Errorf("Fail to rewrap '%s'.\nWant:\n%#v\n\nGot :\n%#v", tc.in, tc.want, got)
+		}
+	}
+}
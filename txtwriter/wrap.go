@@ -5,6 +5,7 @@ import (
 	"unicode"
 
 	"github.com/pirmd/text/ansi"
+	"github.com/pirmd/text/terminal"
 	"github.com/pirmd/text/visual"
 )
 
@@ -17,6 +18,16 @@ func (w *Writer) SetMaxWidth(maxwidth int) *Writer {
 	return w
 }
 
+// SetTerminal sets Writer's maximum width to the width of the Terminal the
+// text is to be displayed on, if it can be determined.
+func (w *Writer) SetTerminal(term terminal.Terminal) *Writer {
+	if width, _, ok := term.Size(); ok {
+		w.maxwidth = width
+	}
+
+	return w
+}
+
 // LazyWrap sets Writer's wrapping mode to not break in the middle of words
 // whose "visual" width are above Writer's maximum width.
 func (w *Writer) LazyWrap() *Writer {
@@ -25,40 +36,64 @@ func (w *Writer) LazyWrap() *Writer {
 	return w
 }
 
+// OptimalWrap sets Writer's wrapping mode to split paragraphs so that lines'
+// "visual" widths are as even as possible (minimum raggedness) instead of
+// filling each line as much as possible.
+// As a paragraph is only wrapped once complete, that is to say once ended by
+// an end-of-line, Flush is needed to output the last paragraph.
+func (w *Writer) OptimalWrap() *Writer {
+	w.optimalwrap = true
+
+	return w
+}
+
+// NoWrap sets Writer to write lines as is, without wrapping them nor
+// trimming their spaces. Only indentation and prefixes are added, notably to
+// write preformatted text like code or tables.
+func (w *Writer) NoWrap() *Writer {
+	w.nowrap = true
+
+	return w
+}
+
+// Width returns the "visual" width available for text once indentation and
+// current prefix are accounted for.
+func (w *Writer) Width() int {
+	return w.width()
+}
+
 func (w Writer) newCutter() *visual.Cutter {
+	var cutr *visual.Cutter
 	if w.lazywrap {
-		return visual.NewLazyCutter(w.width())
+		cutr = w.profile.NewLazyCutter(w.width())
+	} else {
+		cutr = w.profile.NewCutter(w.width())
 	}
+	cutr.Optimal = w.optimalwrap
 
-	return visual.NewCutter(w.width())
-
+	return cutr
 }
 
 func (w *Writer) writeWithWrap(p []byte) (n int, err error) {
-	cutr := w.newCutter()
-
 	// Append the current buffer to p to catch-up with previous wrapping
 	// operation that did not fully complete.
 	in := append(w.curline.Bytes(), p...)
 
-	in = trimLeadingSpace(in)
-	line, tail := cutr.Split(in)
-	for line != nil {
-		// trim every trailing spaces and eol from line to get a cleaner visual output.
-		// It does simplify the life of writeLine in getting proper
-		// left/right/center alignment and padding. It also ensures that we
-		// have to add a '\n' without worrying doubling it if already existing.
-		line = visual.TrimTrailingSpace(line)
-		if err = w.writeLine(line); err != nil {
-			return
-		}
-		if _, err = w.out.Write([]byte{'\n'}); err != nil {
-			return
-		}
+	// Expand tabs so that they keep their meaning whatever the indentation,
+	// prefix or alignment of the line.
+	in = []byte(w.profile.ExpandTabs(string(in)))
 
-		// trim leading spaces from tail to get a proper visual alignment feeling.
-		in = trimLeadingSpace(tail)
-		line, tail = cutr.Split(in)
+	// Optimal wrapping needs complete paragraphs, the incomplete last one
+	// waits for the next Write or for Flush.
+	var pending []byte
+	if w.optimalwrap {
+		i := bytes.LastIndexByte(in, '\n') + 1
+		in, pending = in[:i], in[i:]
+	}
+
+	tail, err := w.wrap(in)
+	if err != nil {
+		return
 	}
 
 	// Memorize remaining text that is not detected as either being ended by a
@@ -67,6 +102,7 @@ func (w *Writer) writeWithWrap(p []byte) (n int, err error) {
 	// Writer.out.
 	w.curline.Reset()
 	w.curline.Write(tail)
+	w.curline.Write(pending)
 
 	// TODO: how to properly capture the number of bytes actually processed by
 	// Write (taking into account trimming operation and initial addition of
@@ -76,6 +112,81 @@ func (w *Writer) writeWithWrap(p []byte) (n int, err error) {
 	return
 }
 
+// wrap writes the lines of in that are ended by an end-of-line or that are
+// longer than maximum width. It returns the remaining text.
+func (w *Writer) wrap(in []byte) (tail []byte, err error) {
+	if w.nowrap {
+		return w.writeLines(in)
+	}
+
+	cutr := w.newCutter()
+	in = trimLeadingSpace(in)
+
+	// optimal wrapping lays out each paragraph as a whole.
+	if w.optimalwrap {
+		lines, tail := cutr.Lines(in)
+		for _, line := range lines {
+			if err = w.writeWrappedLine(trimLeadingSpace(line)); err != nil {
+				return nil, err
+			}
+		}
+		return tail, nil
+	}
+
+	line, tail := cutr.Split(in)
+	for line != nil {
+		if err = w.writeWrappedLine(line); err != nil {
+			return
+		}
+
+		// trim leading spaces from tail to get a proper visual alignment feeling.
+		in = trimLeadingSpace(tail)
+		line, tail = cutr.Split(in)
+	}
+
+	return
+}
+
+// writeWrappedLine writes a line cut by wrap followed by an end-of-line.
+func (w *Writer) writeWrappedLine(line []byte) error {
+	// trim every trailing spaces and eol from line to get a cleaner visual output.
+	// It does simplify the life of writeLine in getting proper
+	// left/right/center alignment and padding. It also ensures that we
+	// have to add a '\n' without worrying doubling it if already existing.
+	isEndOfParagraph := bytes.ContainsRune(line, '\n')
+	line = visual.TrimTrailingSpace(line)
+	if w.alignment == alignJustify && !isEndOfParagraph {
+		// a line made of a single word is not justified but
+		// aligned to the left.
+		line = visual.TrimTrailingSpace(w.profile.Justify(line, w.width()))
+	}
+	if err := w.writeLine(line); err != nil {
+		return err
+	}
+
+	_, err := w.out.Write([]byte{'\n'})
+	return err
+}
+
+// writeLines writes the lines of in that are ended by an end-of-line as is. It
+// returns the remaining text.
+func (w *Writer) writeLines(in []byte) (tail []byte, err error) {
+	for {
+		i := bytes.IndexByte(in, '\n')
+		if i < 0 {
+			return in, nil
+		}
+
+		if err = w.writeLine(in[:i]); err != nil {
+			return
+		}
+		if _, err = w.out.Write([]byte{'\n'}); err != nil {
+			return
+		}
+		in = in[i+1:]
+	}
+}
+
 // trimLeadingSpace is a copy of visual.TrimLeadingSpace that does not consider
 // eol as a space.
 func trimLeadingSpace(s []byte) []byte {
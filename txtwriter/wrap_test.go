@@ -3,6 +3,8 @@ package txtwriter
 import (
 	"strings"
 	"testing"
+
+	"github.com/pirmd/text/terminal"
 )
 
 func TestWrap(t *testing.T) {
@@ -38,6 +40,10 @@ func TestWrap(t *testing.T) {
 			in:   "My favorite words are: Supercalifragilisticexpialidocious\n\nChim Chim Cher-ee",
 			want: "My favorite\nwords are:\nSupercalifra\ngilisticexpi\nalidocious\n\nChim Chim\nCher-ee",
 		},
+		{
+			in:   "a\tb\nab\tc",
+			want: "a       b\nab      c",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -153,6 +159,69 @@ func TestLazyWrap(t *testing.T) {
 	}
 }
 
+func TestWrapWithTerminal(t *testing.T) {
+	testCases := []struct {
+		term *terminal.Fake
+		want string
+	}{
+		{&terminal.Fake{Width: 12}, "Hello ladies\nand\ngentlemen!"},
+		{&terminal.Fake{Width: 20}, "Hello ladies and\ngentlemen!"},
+		{&terminal.Fake{}, "Hello ladies and gentlemen!"},
+	}
+
+	for _, tc := range testCases {
+		got := new(strings.Builder)
+
+		tstwriter := New(got).SetTerminal(tc.term)
+
+		tstwriter.Write([]byte("Hello ladies and gentlemen!"))
+		tstwriter.Flush()
+
+		if got.String() != tc.want {
+			t.Errorf("Fail to write with terminal %#v.\nWant:\n%#v\n\nGot :\n%#v", tc.term, tc.want, got.String())
+		}
+	}
+}
+
+func TestOptimalWrap(t *testing.T) {
+	testCases := []struct {
+		in   []string
+		want string
+	}{
+		{
+			in:   []string{"Hello world!"},
+			want: "Hello\nworld!",
+		},
+		{
+			in:   []string{"aaa bb cc ddddd"},
+			want: "aaa\nbb cc\nddddd",
+		},
+		{
+			in:   []string{"aaa bb", " cc ddddd\n", "\n", "aaa bb cc ddddd"},
+			want: "aaa\nbb cc\nddddd\n\naaa\nbb cc\nddddd",
+		},
+		{
+			in:   []string{"Supercalifragilistic word"},
+			want: "Super\ncalif\nragil\nistic\nword",
+		},
+	}
+
+	for _, tc := range testCases {
+		got := new(strings.Builder)
+
+		tstwriter := New(got).SetMaxWidth(6).OptimalWrap()
+
+		for _, in := range tc.in {
+			tstwriter.Write([]byte(in))
+		}
+		tstwriter.Flush()
+
+		if got.String() != tc.want {
+			t.Errorf("Fail to write '%#v'.\nWant:\n%#v\n\nGot :\n%#v", tc.in, tc.want, got.String())
+		}
+	}
+}
+
 func TestMultipleWrite(t *testing.T) {
 	testCases := []struct {
 		in   []string
@@ -3,6 +3,8 @@ package txtwriter
 import (
 	"bytes"
 	"io"
+
+	"github.com/pirmd/text/visual"
 )
 
 // Writer represents a text's Writer that knows how to format input text that
@@ -21,9 +23,13 @@ type Writer struct {
 	indentScheme  []byte
 	blockindent   bool
 	lazywrap      bool
+	optimalwrap   bool
+	nowrap        bool
 	padWithSpaces bool
 	alignment     alignment
 	prefixes      []string
+	trimBlank     bool
+	profile       visual.Profile
 
 	//TODO: add support to interrupt ANSI at each line (get inspiration from
 	//table module).
@@ -45,10 +51,19 @@ func New(out io.Writer) *Writer {
 	return &Writer{
 		out:          out,
 		indentScheme: defaultIndentScheme,
+		profile:      visual.DefaultProfile,
 		curline:      new(bytes.Buffer),
 	}
 }
 
+// SetProfile sets the Profile used to measure the text's "visual" width.
+// Default to visual.DefaultProfile.
+func (w *Writer) SetProfile(pf visual.Profile) *Writer {
+	w.profile = pf
+	w.prefixWidth = pf.Width(w.prefix)
+	return w
+}
+
 // Write appends the content of p to Writer's current line. Current line is
 // written to Writer's output when the current line reaches Writer's maximum
 // width (if any) or when an end-of-line is encountered.
@@ -61,6 +76,15 @@ func (w *Writer) Write(p []byte) (n int, err error) {
 // notably incomplete lines or words (line that a have not reached maximum
 // width yet or end-of-line).
 func (w *Writer) Flush() error {
+	if w.optimalwrap {
+		tail, err := w.wrap(w.curline.Bytes())
+		if err != nil {
+			return err
+		}
+		w.curline.Reset()
+		w.curline.Write(tail)
+	}
+
 	return w.writeLine(w.curline.Bytes())
 }
 
@@ -79,7 +103,7 @@ func (w *Writer) writeLine(p []byte) (err error) {
 		return
 	}
 
-	if _, err = w.writePrefix(); err != nil {
+	if _, err = w.writePrefix(w.trimBlank && len(p) == 0 && !w.padWithSpaces); err != nil {
 		return
 	}
 
@@ -3,6 +3,8 @@ package txtwriter
 import (
 	"strings"
 	"testing"
+
+	"github.com/pirmd/text/visual"
 )
 
 func TestWriteWithoutWrap(t *testing.T) {
@@ -41,3 +43,26 @@ func TestWriteWithoutWrap(t *testing.T) {
 		}
 	}
 }
+
+func TestWriteWithProfile(t *testing.T) {
+	testCases := []struct {
+		profile visual.Profile
+		want    string
+	}{
+		{visual.Profile{}, "  ○○○○"},
+		{visual.Profile{AmbiguousWide: true}, "○○○\n    ○"},
+	}
+
+	for _, tc := range testCases {
+		got := new(strings.Builder)
+
+		tstwriter := New(got).SetProfile(tc.profile).SetMaxWidth(6).AlignRight()
+
+		tstwriter.Write([]byte("○○○○"))
+		tstwriter.Flush()
+
+		if got.String() != tc.want {
+			t.Errorf("Fail to write using profile %#v.\nWant:\n%#v\n\nGot :\n%#v", tc.profile, tc.want, got.String())
+		}
+	}
+}
@@ -3,6 +3,7 @@ package visual
 import (
 	"bytes"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/pirmd/text/ansi"
 )
@@ -18,6 +19,8 @@ var (
 // stay as close as possible under the maximum limit.
 // Should a word exist that is longer than the limit, the word is either
 // split in chunk or kept as is depending on Lazycut flag.
+// Cutter never splits a grapheme cluster. Tabs are measured up to the next tab
+// stop, columns being counted from the beginning of the line being cut.
 type Cutter struct {
 	// Maxwidth is the maximum line's "visual" length at which the Cutter
 	// force a split.
@@ -30,23 +33,56 @@ type Cutter struct {
 	// WordBoundaries list the byte considered as word boundaries where a
 	// Cutter can split a line.
 	WordBoundaries []byte
+	// UnicodeLineBreaks flag switches the Cutter to the Unicode line breaking
+	// algorithm (UAX #14) to find where a line can be split instead of
+	// relying on spaces and WordBoundaries. It notably allows splitting CJK
+	// text or URLs and honours non-breaking spaces.
+	UnicodeLineBreaks bool
+	// Optimal flag switches the Cutter to optimal-fit wrapping: instead of
+	// filling each line as much as possible, the Cutter considers the whole
+	// paragraph (up to the next end-of-line) and chooses the split points
+	// that minimize its raggedness, that is to say the sum of the squares of
+	// the free space left at the end of each line but the last one (Knuth
+	// and Plass' "minimum raggedness"). Hyphenation is not supported in this
+	// mode.
+	Optimal bool
+	// Hyphenator, if set, is used to split words that do not fit at the end
+	// of a line at an hyphenation point, terminating the line by an hyphen.
+	// Soft hyphens (U+00AD) found in the text are always considered as
+	// hyphenation points.
+	Hyphenator Hyphenator
+	// Profile is used to measure the "visual" length of lines.
+	Profile Profile
 }
 
-// NewCutter creates a new Cutter.
+// NewCutter creates a new Cutter that uses the DefaultProfile.
 func NewCutter(maxwidth int) *Cutter {
+	return DefaultProfile.NewCutter(maxwidth)
+}
+
+// NewCutter creates a new Cutter that uses the Profile.
+func (pf Profile) NewCutter(maxwidth int) *Cutter {
 	return &Cutter{
 		Maxwidth:       maxwidth,
 		WordBoundaries: defaultWordBoundaries,
+		Profile:        pf,
 	}
 }
 
 // NewLazyCutter creates a new Cutter that lazily manage word longer than
-// maxwidth.
+// maxwidth and uses the DefaultProfile.
 func NewLazyCutter(maxwidth int) *Cutter {
+	return DefaultProfile.NewLazyCutter(maxwidth)
+}
+
+// NewLazyCutter creates a new Cutter that lazily manage word longer than
+// maxwidth and uses the Profile.
+func (pf Profile) NewLazyCutter(maxwidth int) *Cutter {
 	return &Cutter{
 		Maxwidth:       maxwidth,
 		Lazycut:        true,
 		WordBoundaries: defaultWordBoundaries,
+		Profile:        pf,
 	}
 }
 
@@ -56,19 +92,56 @@ func NewLazyCutter(maxwidth int) *Cutter {
 // nor the "visual" width be reached, result will be nil, s.
 func (cutr Cutter) Split(s []byte) ([]byte, []byte) {
 	if ieol := cutr.nextEOL(s); ieol >= 0 {
+		if line, next, ok := cutr.hyphenate(s, ieol); ok {
+			return line, s[next:]
+		}
 		return s[:ieol], s[ieol:]
 	}
 
+	// a lazy Cutter keeps too long words as is, give them a last chance to
+	// be split at an hyphenation point.
+	if cutr.Lazycut {
+		if line, next, ok := cutr.hyphenate(s, len(s)); ok {
+			return line, s[next:]
+		}
+	}
+
 	return nil, s
 }
 
+// Lines cuts s into lines like successive calls to Split do. It returns the
+// lines and the remaining of s that is not cut, being neither ended by an
+// end-of-line nor longer than the Cutter's maximum width.
+// Unlike successive calls to Split, an optimal Cutter lays out each paragraph
+// only once.
+func (cutr Cutter) Lines(s []byte) (lines [][]byte, tail []byte) {
+	if cutr.Optimal && cutr.Maxwidth > 0 {
+		return cutr.optimalLines(s)
+	}
+
+	line, tail := cutr.Split(s)
+	for line != nil {
+		lines = append(lines, line)
+		line, tail = cutr.Split(tail)
+	}
+
+	return lines, tail
+}
+
 // SplitFunc implements bufio.SplitFunc for using Cutter with a bufio.Scanner.
 func (cutr Cutter) SplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if cutr.Optimal {
+		return cutr.optimalSplitFunc(data, atEOF)
+	}
+
 	if atEOF && len(data) > 0 {
 		return len(data), data, nil
 	}
 
 	if ieol := cutr.nextEOL(data); ieol >= 0 {
+		if line, next, ok := cutr.hyphenate(data, ieol); ok {
+			return next, line, nil
+		}
 		return ieol, data[:ieol], nil
 	}
 
@@ -82,6 +155,14 @@ func (cutr Cutter) SplitFunc(data []byte, atEOF bool) (advance int, token []byte
 // if maximum line's visual length is negative or null, nextEOL only returns
 // next '\n' position.
 func (cutr Cutter) nextEOL(data []byte) int {
+	if cutr.Optimal && cutr.Maxwidth > 0 {
+		return cutr.nextOptimalEOL(data)
+	}
+
+	if cutr.UnicodeLineBreaks {
+		return cutr.nextLineBreak(data)
+	}
+
 	if cutr.Maxwidth <= 0 {
 		if n := bytes.IndexRune(data, '\n'); n != -1 {
 			return n + 1
@@ -92,8 +173,8 @@ func (cutr Cutter) nextEOL(data []byte) int {
 	ieol, iword := -1, -1
 	i, w := 0, 0
 
-	_ = ansi.Walk(data, func(n int, c rune, esc string) error {
-		runewidth := Runewidth(c)
+	_ = cutr.Profile.walkGraphemes(data, func(n int, cluster string, runewidth int, esc string) error {
+		c, _ := utf8.DecodeLastRuneInString(cluster)
 
 		switch {
 
@@ -120,6 +201,11 @@ func (cutr Cutter) nextEOL(data []byte) int {
 			return nil // end-of line is found, continue in case there is a remaining consecutive escape sequence.
 
 		case unicode.IsSpace(c):
+			// a tab only extends up to the end of the line.
+			if c == '\t' && w+runewidth > cutr.Maxwidth {
+				runewidth = cutr.Maxwidth - w
+			}
+
 			if w+runewidth > cutr.Maxwidth {
 				ieol = i
 				return ansi.ErrStopWalk
@@ -140,6 +226,10 @@ func (cutr Cutter) nextEOL(data []byte) int {
 		default:
 			if w+runewidth > cutr.Maxwidth {
 				switch {
+				// current grapheme cluster is larger than maximum width, a
+				// line holds at least one grapheme cluster.
+				case w == 0:
+
 				// current word will be longer than maximum width; we are lazy,
 				// so we do nothing to prevent that.
 				case iword == -1 && cutr.Lazycut:
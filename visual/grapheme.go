@@ -0,0 +1,86 @@
+package visual
+
+import (
+	"github.com/rivo/uniseg"
+
+	"github.com/pirmd/text/ansi"
+)
+
+// graphemeWalkFunc is the type of the function that is called while going
+// through a slice of bytes grapheme cluster per grapheme cluster.
+// If graphemeWalkFunc is called for an ANSI escape sequence, the cluster is
+// empty and esc holds the escape sequence.
+type graphemeWalkFunc func(advance int, cluster string, width int, esc string) error
+
+// walkGraphemes walks through a slice of bytes that can contain ANSI escape
+// codes and runs fn either on each grapheme cluster (like an emoji followed
+// by a skin-tone modifier or a letter followed by combining marks) that is not
+// part of an escape sequence or on each ANSI escape sequence.
+// Grapheme clusters interrupted by an ANSI escape sequence are walked as two
+// separate clusters.
+// walkGraphemes stops and returns any error raised by fn (that is not
+// ansi.ErrStopWalk).
+// Clusters' width is measured according to the Profile, the width of tabs
+// depending on the column they are found at.
+func (pf Profile) walkGraphemes(p []byte, fn graphemeWalkFunc) error {
+	start, end, col, state := 0, 0, 0, -1
+	stopped := false
+
+	// flush walks through the grapheme clusters found since the last ANSI
+	// escape sequence. Unless all is true, the last cluster is kept until
+	// the next rune is known, as it might extend it.
+	flush := func(all bool) error {
+		for start < end {
+			cluster, rest, _, st := uniseg.FirstGraphemeCluster(p[start:end], state)
+			if len(rest) == 0 && !all {
+				return nil
+			}
+			start, state = start+len(cluster), st
+
+			w := pf.clusterWidth(cluster, col)
+			if col += w; cluster[len(cluster)-1] == '\n' {
+				col = 0
+			}
+
+			if err := fn(start, string(cluster), w, ""); err != nil {
+				stopped = true
+				return err
+			}
+		}
+		if all {
+			state = -1
+		}
+		return nil
+	}
+
+	err := ansi.Walk(p, func(n int, c rune, esc string) error {
+		if c > -1 {
+			end = n
+			return flush(false)
+		}
+
+		if err := flush(true); err != nil {
+			return err
+		}
+		start, end = n, n
+		if err := fn(n, "", 0, esc); err != nil {
+			stopped = true
+			return err
+		}
+		return nil
+	})
+	if err != nil || stopped {
+		return err
+	}
+
+	if err := flush(true); err != nil && err != ansi.ErrStopWalk {
+		return err
+	}
+	return nil
+}
+
+// walkGraphemesString walks through a string that can contain ANSI escape
+// codes grapheme cluster per grapheme cluster.
+func (pf Profile) walkGraphemesString(s string, fn graphemeWalkFunc) error {
+	return pf.walkGraphemes([]byte(s), fn)
+}
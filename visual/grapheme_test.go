@@ -0,0 +1,47 @@
+package visual
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pirmd/text/ansi"
+)
+
+func TestWalkGraphemes(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want []string
+	}{
+		{"abc", []string{"a", "b", "c"}},
+		{"été", []string{"é", "t", "é"}},
+		{"👍🏽🇫🇷", []string{"👍🏽", "🇫🇷"}},
+		{"a\x1b[1m👨‍👩‍👧\x1b[0m", []string{"a", "\x1b[1m", "👨‍👩‍👧", "\x1b[0m"}},
+	}
+
+	for _, tc := range testCases {
+		var got []string
+		_ = DefaultProfile.walkGraphemesString(tc.in, func(n int, cluster string, w int, esc string) error {
+			got = append(got, cluster+esc)
+			return nil
+		})
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Walking grapheme clusters failed for %#v.\nWant: %#v\nGot : %#v", tc.in, tc.want, got)
+		}
+	}
+}
+
+func TestWalkGraphemesStop(t *testing.T) {
+	var got []string
+	_ = DefaultProfile.walkGraphemesString("ab\x1b[1mcd", func(n int, cluster string, w int, esc string) error {
+		got = append(got, cluster+esc)
+		if cluster == "b" {
+			return ansi.ErrStopWalk
+		}
+		return nil
+	})
+
+	if want := []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Walking grapheme clusters failed to stop.\nWant: %#v\nGot : %#v", want, got)
+	}
+}
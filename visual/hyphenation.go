@@ -0,0 +1,173 @@
+package visual
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+const softHyphen = "\u00ad"
+
+// Hyphenator is the interface implemented by hyphenation engines (like
+// hyphen.Patterns) that find where words can be split.
+type Hyphenator interface {
+	// Hyphenate returns the offsets (in bytes) at which word can be split by
+	// inserting an hyphen.
+	Hyphenate(word string) []int
+}
+
+// hyphenate looks for a better place than ieol to split s by hyphenating the
+// word found at the end of the line, either the word that follows ieol, the
+// word ieol is in the middle of or, for lazy Cutters, the last word of the
+// line if it is too long.
+// Words are split at soft hyphens (U+00AD) or at the hyphenation points found
+// by the Cutter's Hyphenator. hyphenate returns the line, terminated by an
+// hyphen, and the offset in s where the next line starts.
+func (cutr Cutter) hyphenate(s []byte, ieol int) ([]byte, int, bool) {
+	if cutr.Maxwidth <= 0 || cutr.Optimal {
+		return nil, 0, false
+	}
+
+	// only consider s up to the end of the word that follows ieol.
+	if i := bytes.IndexFunc(s[ieol:], unicode.IsSpace); i >= 0 {
+		s = s[:ieol+i]
+	}
+	if cutr.Hyphenator == nil && !bytes.Contains(s, []byte(softHyphen)) {
+		return nil, 0, false
+	}
+
+	plain, offsets := plainText(string(s))
+	pos := sort.SearchInts(offsets, ieol)
+
+	toData := func(i int) int {
+		if i >= len(offsets) {
+			return len(s)
+		}
+		return offsets[i]
+	}
+
+	if pos > 0 && plain[pos-1] == '\n' {
+		return nil, 0, false
+	}
+
+	// a line that ends by a soft hyphen only needs to make it visible.
+	if strings.HasSuffix(plain[:pos], softHyphen) {
+		i := offsets[pos-len(softHyphen)]
+		return joinHyphen(s[:i], s[i+len(softHyphen):ieol]), ieol, true
+	}
+
+	// minPos is the position from which an hyphenation point is an
+	// improvement compared to ieol.
+	minPos := pos
+	if !cutr.isBreakOpportunity(plain, pos) {
+		minPos = 0
+	}
+
+	if lastWord := strings.TrimRightFunc(plain[:pos], unicode.IsSpace); cutr.Lazycut && len(lastWord) > 0 {
+		if cutr.Profile.Width(s[:toData(len(lastWord))]) > cutr.Maxwidth {
+			pos, minPos = len(lastWord), 0
+		}
+	}
+
+	start, end := pos, pos
+	for start > 0 {
+		c, sz := utf8.DecodeLastRuneInString(plain[:start])
+		if unicode.IsSpace(c) {
+			break
+		}
+		start -= sz
+	}
+	for end < len(plain) {
+		c, sz := utf8.DecodeRuneInString(plain[end:])
+		if unicode.IsSpace(c) {
+			break
+		}
+		end += sz
+	}
+
+	for _, split := range cutr.hyphenationPoints(plain[start:end]) {
+		if split.at == 0 || start+split.at < minPos {
+			continue
+		}
+
+		i := offsets[start+split.at]
+		if cutr.Profile.Width(s[:i])+1 <= cutr.Maxwidth {
+			return joinHyphen(s[:i]), toData(start + split.next), true
+		}
+	}
+
+	return nil, 0, false
+}
+
+// isBreakOpportunity reports whether the Cutter can naturally split the line
+// at the given position of plain.
+func (cutr Cutter) isBreakOpportunity(plain string, pos int) bool {
+	if pos == 0 || pos >= len(plain) {
+		return true
+	}
+
+	if cutr.UnicodeLineBreaks {
+		state := -1
+		for i := 0; i < pos; {
+			var segment string
+			segment, _, _, state = uniseg.FirstLineSegmentInString(plain[i:], state)
+			i += len(segment)
+			if i == pos {
+				return true
+			}
+		}
+		return false
+	}
+
+	c, _ := utf8.DecodeLastRuneInString(plain[:pos])
+	return unicode.IsSpace(c) || strings.ContainsRune(string(cutr.WordBoundaries), c)
+}
+
+type hyphenationPoint struct {
+	at, next int
+}
+
+// hyphenationPoints returns the points where word can be hyphenated, from the
+// last one to the first one, either at a soft hyphen or at the points found by
+// the Cutter's Hyphenator.
+func (cutr Cutter) hyphenationPoints(word string) (points []hyphenationPoint) {
+	// stripped is word without soft hyphens, pos giving for each of its
+	// bytes the corresponding position in word.
+	var stripped strings.Builder
+	var pos []int
+
+	for i := 0; i < len(word); {
+		if strings.HasPrefix(word[i:], softHyphen) {
+			points = append(points, hyphenationPoint{i, i + len(softHyphen)})
+			i += len(softHyphen)
+			continue
+		}
+		stripped.WriteByte(word[i])
+		pos = append(pos, i)
+		i++
+	}
+
+	if cutr.Hyphenator != nil {
+		for _, i := range cutr.Hyphenator.Hyphenate(stripped.String()) {
+			if i > 0 && i < len(pos) {
+				points = append(points, hyphenationPoint{pos[i], pos[i]})
+			}
+		}
+	}
+
+	sort.Slice(points, func(i, j int) bool { return points[i].at > points[j].at })
+	return
+}
+
+// joinHyphen concatenates parts and terminates the result by an hyphen.
+func joinHyphen(parts ...[]byte) []byte {
+	var line []byte
+	for _, p := range parts {
+		line = append(line, p...)
+	}
+	return append(line, '-')
+}
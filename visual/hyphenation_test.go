@@ -0,0 +1,58 @@
+package visual
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pirmd/text/hyphen"
+)
+
+func TestHyphenation(t *testing.T) {
+	testCases := []struct {
+		in   string
+		sz   int
+		lazy bool
+		want []string
+	}{
+		{"Supercalifragilistic", 10, false, []string{"Supercal-", "ifragilis-", "tic"}},
+		{"Supercalifragilistic", 10, true, []string{"Supercal-", "ifragilis-", "tic"}},
+		{"The hyphenation algorithm", 12, false, []string{"The hyphen-", "ation algo-", "rithm"}},
+		{"The \x1b[1mhyphenation\x1b[0m algorithm", 12, false, []string{"The \x1b[1mhyphen-", "ation\x1b[0m algo-", "rithm"}},
+		{"Chim Chim Cher-ee", 10, false, []string{"Chim Chim ", "Cher-ee"}},
+		{"A table", 6, false, []string{"A ", "table"}},
+		{"A cat\nhyphenation", 9, false, []string{"A cat\n", "hyphen-", "ation"}},
+	}
+
+	for _, tc := range testCases {
+		cutr := NewCutter(tc.sz)
+		cutr.Lazycut, cutr.Hyphenator = tc.lazy, hyphen.English()
+
+		if got := cut(tc.in, cutr); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Cutting %#v at %d with hyphenation failed.\nWant: %#v\nGot : %#v", tc.in, tc.sz, tc.want, got)
+		}
+	}
+}
+
+func TestSoftHyphen(t *testing.T) {
+	testCases := []struct {
+		in      string
+		sz      int
+		unicode bool
+		want    []string
+	}{
+		{"A hyphen\u00adation", 10, false, []string{"A hyphen-", "ation"}},
+		{"A hyphen\u00adation", 10, true, []string{"A hyphen-", "ation"}},
+		{"A hyphen\u00adation", 8, true, []string{"A ", "hyphen-", "ation"}},
+		{"A hyphen\u00adation", 20, false, []string{"A hyphen\u00adation"}},
+		{"hy\u00adphen\u00adation", 7, false, []string{"hy\u00adphen-", "ation"}},
+	}
+
+	for _, tc := range testCases {
+		cutr := NewCutter(tc.sz)
+		cutr.UnicodeLineBreaks = tc.unicode
+
+		if got := cut(tc.in, cutr); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Cutting %#v at %d with soft hyphens failed.\nWant: %#v\nGot : %#v", tc.in, tc.sz, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package visual
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+
+	"github.com/pirmd/text/ansi"
+)
+
+// nextLineBreak is the counterpart of nextEOL that relies on the Unicode line
+// breaking algorithm (UAX #14) to find break opportunities.
+// Trailing spaces of a line are kept in the line without being accounted for
+// its "visual" width.
+func (cutr Cutter) nextLineBreak(data []byte) int {
+	plain, offsets, cols, size := cutr.Profile.plainColumns(data)
+
+	// toData converts an offset in plain into an offset in data. ANSI escape
+	// sequences found at a break opportunity are kept with the first line.
+	toData := func(i int) int {
+		if i >= len(plain) {
+			return size
+		}
+		return offsets[i]
+	}
+
+	widthAt := func(i int) int {
+		if i == 0 {
+			return 0
+		}
+		return cols[i-1]
+	}
+
+	state, lastBreak := -1, 0
+	for pos := 0; pos < len(plain); {
+		var segment []byte
+		var mustBreak bool
+		segment, _, mustBreak, state = uniseg.FirstLineSegment(plain[pos:], state)
+		end := pos + len(segment)
+
+		visibleEnd := pos + len(bytes.TrimRightFunc(segment, unicode.IsSpace))
+		w := widthAt(visibleEnd)
+		if bytes.HasSuffix(segment, []byte(softHyphen)) {
+			// soft hyphen is made visible if the line is split after it.
+			w++
+		}
+
+		if cutr.Maxwidth > 0 && w > cutr.Maxwidth {
+			switch {
+			case lastBreak > 0:
+				return toData(lastBreak)
+
+			// current segment is longer than maximum width; we are lazy,
+			// so we keep it as is.
+			case cutr.Lazycut:
+				if end == len(plain) && !isMandatoryBreak(segment) {
+					return -1
+				}
+				return toData(end)
+
+			// current segment is longer than maximum width; we are not lazy,
+			// so we cut it in pieces, keeping at least one grapheme cluster.
+			default:
+				i := 1
+				for i < visibleEnd && (cols[i-1] == cols[i] || cols[i] <= cutr.Maxwidth) {
+					i++
+				}
+				return toData(i)
+			}
+		}
+
+		if mustBreak && isMandatoryBreak(segment) {
+			return toData(end)
+		}
+
+		lastBreak, pos = end, end
+	}
+
+	return -1
+}
+
+// plainColumns returns the first paragraph of data, that is to say up to the
+// first end-of-line and the ANSI escape sequences that follow it, without its
+// ANSI escape sequences. For each byte of the result, offsets gives the
+// corresponding offset in data and cols the column reached at the end of the
+// grapheme cluster it belongs to. size is the paragraph's length in data.
+func (pf Profile) plainColumns(data []byte) (plain []byte, offsets []int, cols []int, size int) {
+	var col int
+	var eop bool
+
+	_ = pf.walkGraphemes(data, func(n int, cluster string, w int, esc string) error {
+		if len(esc) > 0 {
+			size = n
+			return nil
+		}
+
+		if eop {
+			return ansi.ErrStopWalk
+		}
+
+		col += w
+		for i := 0; i < len(cluster); i++ {
+			offsets = append(offsets, n-len(cluster)+i)
+			cols = append(cols, col)
+		}
+		plain = append(plain, cluster...)
+		size, eop = n, cluster[len(cluster)-1] == '\n'
+		return nil
+	})
+
+	return
+}
+
+// isMandatoryBreak reports whether a line's segment ends with a character
+// that forces a line break.
+func isMandatoryBreak(segment []byte) bool {
+	c, _ := utf8.DecodeLastRune(segment)
+	switch c {
+	case '\n', '\r', '\v', '\f', '\u0085', '\u2028', '\u2029':
+		return true
+	}
+	return false
+}
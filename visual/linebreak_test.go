@@ -0,0 +1,70 @@
+package visual
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnicodeLineBreaks(t *testing.T) {
+	testCases := []struct {
+		in   string
+		sz   int
+		lazy bool
+		want []string
+	}{
+		{"Coucou", 10, false, []string{"Coucou"}},
+		{"Coucou\n", 6, false, []string{"Coucou\n"}},
+		{"This is a long sentence", 10, false, []string{"This is a ", "long ", "sentence"}},
+		{"This \x1b[34mis a long sentence\x1b[0m", 10, false, []string{"This \x1b[34mis a ", "long ", "sentence\x1b[0m"}},
+		{"\x1b[34mCoucou\n\x1b[0mHello", 10, false, []string{"\x1b[34mCoucou\n\x1b[0m", "Hello"}},
+		{"日本語のテキストです。", 8, false, []string{"日本語の", "テキスト", "です。"}},
+		{"See https://example.com/foo/bar", 20, false, []string{"See https://", "example.com/foo/bar"}},
+		{"Hello world", 8, false, []string{"Hello ", "world"}},
+		{"Hello\u00a0world", 8, false, []string{"Hello\u00a0wo", "rld"}},
+		{"Hello\u00a0world", 8, true, []string{"Hello\u00a0world"}},
+		{"Supercalifragilistic word", 10, false, []string{"Supercalif", "ragilistic ", "word"}},
+		{"Supercalifragilistic word", 10, true, []string{"Supercalifragilistic ", "word"}},
+		{"a\u2028b c", 0, false, []string{"a\u2028", "b c"}},
+	}
+
+	for _, tc := range testCases {
+		cutr := NewCutter(tc.sz)
+		cutr.UnicodeLineBreaks, cutr.Lazycut = true, tc.lazy
+
+		if got := cut(tc.in, cutr); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Cutting %#v at %d using Unicode line breaks failed.\nWant: %#v\nGot : %#v", tc.in, tc.sz, tc.want, got)
+		}
+	}
+}
+
+func TestUnicodeLineBreaksNarrow(t *testing.T) {
+	testCases := []struct {
+		in   string
+		sz   int
+		want []string
+	}{
+		{"\t", 4, []string{"\t"}},
+		{"\t", 1, []string{"\t"}},
+		{"a\tb", 4, []string{"a\t", "b"}},
+		{"ab\tc", 2, []string{"ab\t", "c"}},
+		{"日本", 1, []string{"日", "本"}},
+	}
+
+	for _, tc := range testCases {
+		cutr := Profile{}.NewCutter(tc.sz)
+		cutr.UnicodeLineBreaks = true
+
+		done := make(chan []string, 1)
+		go func() { done <- cut(tc.in, cutr) }()
+
+		select {
+		case got := <-done:
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Cutting %#v at %d using Unicode line breaks failed.\nWant: %#v\nGot : %#v", tc.in, tc.sz, tc.want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Cutting %#v at %d using Unicode line breaks does not terminate", tc.in, tc.sz)
+		}
+	}
+}
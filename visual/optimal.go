@@ -0,0 +1,213 @@
+package visual
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// chopPenalty is the cost of cutting a word in the middle. Costs are summed
+// as int64 so that a paragraph can accumulate many chops without
+// overflowing, even on 32-bit platforms. The penalty is higher than the cost
+// of the free space of any line (at most Maxwidth²) so that a word is only
+// cut if it is longer than the Cutter's maximum width, as long as the
+// paragraph's total free space cost stays below it.
+const chopPenalty int64 = 1 << 40
+
+// breakPoint is a position in a paragraph where a line can end.
+type breakPoint struct {
+	pos  int
+	chop bool
+}
+
+// nextOptimalEOL is the counterpart of nextEOL for optimal Cutters. It returns
+// the end of the first line of the optimal layout of data's first paragraph
+// (up to the first mandatory end-of-line). As for nextEOL, -1 is returned if
+// data is not ended by an end-of-line and already fits in maximum width.
+func (cutr Cutter) nextOptimalEOL(data []byte) int {
+	return cutr.optimalEOLs(data)[0]
+}
+
+// optimalLines is the counterpart of Lines for optimal Cutters, each
+// paragraph being laid out once.
+func (cutr Cutter) optimalLines(s []byte) (lines [][]byte, tail []byte) {
+	for len(s) > 0 {
+		eols := cutr.optimalEOLs(s)
+
+		start := 0
+		for _, eol := range eols {
+			if eol < 0 {
+				return lines, s[start:]
+			}
+			lines = append(lines, s[start:eol])
+			start = eol
+		}
+		s = s[start:]
+	}
+
+	return lines, s
+}
+
+// optimalEOLs lays out data's first paragraph (up to the first mandatory
+// end-of-line) by choosing, among its break opportunities, the ones that
+// minimize the sum of the squares of the lines' free space, the paragraph's
+// last line excepted.
+// It returns the end of each line but the last one, followed by the end of
+// the paragraph or by -1 if data is not ended by an end-of-line.
+func (cutr Cutter) optimalEOLs(data []byte) []int {
+	plain, offsets, cols, size := cutr.Profile.plainColumns(data)
+
+	// toData converts an offset in plain into an offset in data. ANSI escape
+	// sequences found at a break opportunity are kept with the first line.
+	toData := func(i int) int {
+		if i >= len(plain) {
+			return size
+		}
+		return offsets[i]
+	}
+
+	widthAt := func(i int) int {
+		if i == 0 {
+			return 0
+		}
+		return cols[i-1]
+	}
+
+	breaks, eop := cutr.breakPoints(plain, cols)
+	last := len(breaks) - 1
+
+	// lineWidth is the width of the line going from breaks[i] to breaks[j],
+	// trailing spaces excluded.
+	lineWidth := func(i, j int) int {
+		start, end := breaks[i].pos, breaks[j].pos
+		end = start + len(bytes.TrimRightFunc(plain[start:end], unicode.IsSpace))
+		return widthAt(end) - widthAt(start)
+	}
+
+	// cost[i] is the minimal cost of the layout of the paragraph's part that
+	// starts at breaks[i], next[i] being the end of its first line.
+	cost, next := make([]int64, len(breaks)), make([]int, len(breaks))
+	for i := last - 1; i >= 0; i-- {
+		cost[i] = -1
+
+		for j := i + 1; j <= last; j++ {
+			w := lineWidth(i, j)
+
+			// a line is at least made of one word or one grapheme
+			// cluster, even if it is longer than maximum width.
+			if w > cutr.Maxwidth && j > i+1 {
+				break
+			}
+
+			var c int64
+			if free := int64(cutr.Maxwidth - w); j < last && free > 0 {
+				c = free * free
+			}
+			if breaks[j].chop {
+				c += chopPenalty
+			}
+
+			if cost[i] < 0 || c+cost[j] < cost[i] {
+				cost[i], next[i] = c+cost[j], j
+			}
+		}
+	}
+
+	var eols []int
+	for i := next[0]; i < last && i > 0; i = next[i] {
+		eols = append(eols, toData(breaks[i].pos))
+	}
+
+	if eop < 0 {
+		return append(eols, -1)
+	}
+	return append(eols, toData(eop))
+}
+
+// optimalSplitFunc is the counterpart of SplitFunc for optimal Cutters, that
+// need the whole paragraph to choose where to split it.
+func (cutr Cutter) optimalSplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if !atEOF && bytes.IndexByte(data, '\n') < 0 {
+		return 0, nil, nil
+	}
+
+	if ieol := cutr.nextEOL(data); ieol >= 0 {
+		return ieol, data[:ieol], nil
+	}
+
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
+
+// breakPoints lists the positions where plain's first paragraph can be split,
+// the first break point being the paragraph's beginning and the last one its
+// end. It also returns the position of the paragraph's mandatory end-of-line,
+// or -1 if plain does not contain any.
+// Words are only cut in the middle by non lazy Cutters.
+func (cutr Cutter) breakPoints(plain []byte, cols []int) ([]breakPoint, int) {
+	eop := -1
+	opportunities := make(map[int]bool)
+
+	if cutr.UnicodeLineBreaks {
+		state := -1
+		for pos := 0; pos < len(plain); {
+			var segment []byte
+			var mustBreak bool
+			segment, _, mustBreak, state = uniseg.FirstLineSegment(plain[pos:], state)
+			pos += len(segment)
+
+			if mustBreak && isMandatoryBreak(segment) {
+				eop = pos
+				break
+			}
+			// soft hyphens are not made visible by optimal Cutters.
+			if !bytes.HasSuffix(segment, []byte(softHyphen)) {
+				opportunities[pos] = true
+			}
+		}
+	} else {
+		for pos := 0; pos < len(plain); {
+			c, sz := utf8.DecodeRune(plain[pos:])
+			pos += sz
+
+			if c == '\n' {
+				eop = pos
+				break
+			}
+			if unicode.IsSpace(c) || bytes.ContainsRune(cutr.WordBoundaries, c) {
+				opportunities[pos] = true
+			}
+		}
+	}
+
+	end := eop
+	if end < 0 {
+		end = len(plain)
+	}
+
+	breaks := []breakPoint{{pos: 0}}
+	for pos := 1; pos < end; pos++ {
+		c, _ := utf8.DecodeRune(plain[pos:])
+		prev, _ := utf8.DecodeLastRune(plain[:pos])
+
+		switch {
+		// lines start by the first non-space character that follows a
+		// break opportunity.
+		case unicode.IsSpace(c):
+
+		case opportunities[pos] || (unicode.IsSpace(prev) && !cutr.UnicodeLineBreaks):
+			breaks = append(breaks, breakPoint{pos: pos})
+
+		// a new grapheme cluster starts in the middle of a word.
+		case !cutr.Lazycut && !unicode.IsSpace(prev) && cols[pos] > cols[pos-1]:
+			breaks = append(breaks, breakPoint{pos: pos, chop: true})
+		}
+	}
+
+	return append(breaks, breakPoint{pos: end}), eop
+}
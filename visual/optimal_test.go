@@ -0,0 +1,115 @@
+package visual
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOptimalCut(t *testing.T) {
+	testCases := []struct {
+		in   string
+		sz   int
+		lazy bool
+		want []string
+	}{
+		{"Coucou", 10, false, []string{"Coucou"}},
+		{"Coucou\n", 6, false, []string{"Coucou\n"}},
+		{"aaa bb cc ddddd", 6, false, []string{"aaa ", "bb cc ", "ddddd"}},
+		{"aaa bb cc ddddd\naaa bb cc ddddd", 6, false, []string{"aaa ", "bb cc ", "ddddd\n", "aaa ", "bb cc ", "ddddd"}},
+		{"aaa \x1b[34mbb cc\x1b[0m ddddd", 6, false, []string{"aaa \x1b[34m", "bb cc\x1b[0m ", "ddddd"}},
+		{"\x1b[34mCoucou\n\x1b[0mHello", 10, false, []string{"\x1b[34mCoucou\n\x1b[0m", "Hello"}},
+		{"one two three four five six seven", 10, false, []string{"one two ", "three four ", "five six ", "seven"}},
+		{"Supercalifragilistic word", 10, false, []string{"Supercalif", "ragilistic ", "word"}},
+		{"Supercalifragilistic word", 10, true, []string{"Supercalifragilistic ", "word"}},
+		{"aa well-known fact", 8, false, []string{"aa well-", "known ", "fact"}},
+		{"a " + strings.Repeat("x", 25) + " " + strings.Repeat("y", 25) + " b c", 10, false, []string{"a xxxxxxx", "xxxxxxxxx", "xxxxxxxxx ", "yyyyyyyyyy", "yyyyyyyyyy", "yyyyy b c"}},
+	}
+
+	for _, tc := range testCases {
+		cutr := NewCutter(tc.sz)
+		cutr.Optimal, cutr.Lazycut = true, tc.lazy
+
+		if got := cut(tc.in, cutr); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Optimal cutting %#v at %d failed.\nWant: %#v\nGot : %#v", tc.in, tc.sz, tc.want, got)
+		}
+	}
+}
+
+func TestOptimalCutWithUnicodeLineBreaks(t *testing.T) {
+	testCases := []struct {
+		in   string
+		sz   int
+		want []string
+	}{
+		{"aaa bb cc ddddd", 6, []string{"aaa ", "bb cc ", "ddddd"}},
+		{"日本語のテキストです。", 8, []string{"日本語の", "テキスト", "です。"}},
+		{"Hello\u00a0world foo", 12, []string{"Hello\u00a0world ", "foo"}},
+	}
+
+	for _, tc := range testCases {
+		cutr := NewCutter(tc.sz)
+		cutr.Optimal, cutr.UnicodeLineBreaks = true, true
+
+		if got := cut(tc.in, cutr); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Optimal cutting %#v at %d using Unicode line breaks failed.\nWant: %#v\nGot : %#v", tc.in, tc.sz, tc.want, got)
+		}
+	}
+}
+
+func TestOptimalLines(t *testing.T) {
+	testCases := []struct {
+		in        string
+		sz        int
+		wantLines []string
+		wantTail  string
+	}{
+		{"aaa bb cc ddddd", 6, []string{"aaa ", "bb cc "}, "ddddd"},
+		{"aaa bb cc ddddd\naaa bb\n", 6, []string{"aaa ", "bb cc ", "ddddd\n", "aaa bb\n"}, ""},
+		{"aaa bb\n\nccc", 10, []string{"aaa bb\n", "\n"}, "ccc"},
+	}
+
+	for _, tc := range testCases {
+		cutr := NewCutter(tc.sz)
+		cutr.Optimal = true
+
+		lines, tail := cutr.Lines([]byte(tc.in))
+
+		var got []string
+		for _, l := range lines {
+			got = append(got, string(l))
+		}
+		if !reflect.DeepEqual(got, tc.wantLines) || string(tail) != tc.wantTail {
+			t.Errorf("Optimal cutting %#v at %d in lines failed.\nWant: %#v, %#v\nGot : %#v, %#v", tc.in, tc.sz, tc.wantLines, tc.wantTail, got, string(tail))
+		}
+
+		// lines are the same as the ones returned by successive Split.
+		var split []string
+		line, rest := cutr.Split([]byte(tc.in))
+		for ; line != nil; line, rest = cutr.Split(rest) {
+			split = append(split, string(line))
+		}
+		if !reflect.DeepEqual(got, split) || string(tail) != string(rest) {
+			t.Errorf("Optimal cutting %#v at %d in lines differs from splitting it.\nWant: %#v, %#v\nGot : %#v, %#v", tc.in, tc.sz, split, string(rest), got, string(tail))
+		}
+	}
+}
+
+func TestOptimalSplitFunc(t *testing.T) {
+	cutr := NewCutter(6)
+	cutr.Optimal = true
+
+	scanner := bufio.NewScanner(strings.NewReader("aaa bb cc ddddd\naaa bb"))
+	scanner.Split(cutr.SplitFunc)
+
+	var got []string
+	for scanner.Scan() {
+		got = append(got, scanner.Text())
+	}
+
+	want := []string{"aaa ", "bb cc ", "ddddd\n", "aaa bb"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Optimal scanning failed.\nWant: %#v\nGot : %#v", want, got)
+	}
+}
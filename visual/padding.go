@@ -2,35 +2,112 @@ package visual
 
 import (
 	"bytes"
+	"unicode"
+
+	"github.com/pirmd/text/ansi"
 )
 
 // PadRight completes a slice of bytes with spaces until its "visual" size
-// reaches the provided limit.
+// according to the DefaultProfile reaches the provided limit.
 func PadRight(s []byte, sz int) []byte {
+	return DefaultProfile.PadRight(s, sz)
+}
+
+// PadRight completes a slice of bytes with spaces until its "visual" size
+// reaches the provided limit.
+func (pf Profile) PadRight(s []byte, sz int) []byte {
 	in := TrimTrailingSpace(s)
-	if freespace := sz - Width(in); freespace > 0 {
+	if freespace := sz - pf.Width(in); freespace > 0 {
 		return append(in, bytes.Repeat([]byte{' '}, freespace)...)
 	}
 	return in
 }
 
 // PadLeft prefixes a slice of bytes with spaces until its "visual" size
-// reaches the provided limit.
+// according to the DefaultProfile reaches the provided limit.
 func PadLeft(s []byte, sz int) []byte {
+	return DefaultProfile.PadLeft(s, sz)
+}
+
+// PadLeft prefixes a slice of bytes with spaces until its "visual" size
+// reaches the provided limit.
+func (pf Profile) PadLeft(s []byte, sz int) []byte {
 	in := TrimLeadingSpace(s)
-	if freespace := sz - Width(in); freespace > 0 {
+	if freespace := sz - pf.Width(in); freespace > 0 {
 		return append(bytes.Repeat([]byte{' '}, freespace), in...)
 	}
 	return in
 }
 
 // PadCenter equally prefixes and complete a slice of bytes with spaces until
-// its "visual" size reaches the provided limit.
+// its "visual" size according to the DefaultProfile reaches the provided
+// limit.
 func PadCenter(s []byte, sz int) []byte {
+	return DefaultProfile.PadCenter(s, sz)
+}
+
+// PadCenter equally prefixes and complete a slice of bytes with spaces until
+// its "visual" size reaches the provided limit.
+func (pf Profile) PadCenter(s []byte, sz int) []byte {
 	in := TrimSpace(s)
-	if freespace := sz - Width(in); freespace > 0 {
+	if freespace := sz - pf.Width(in); freespace > 0 {
 		in = append(bytes.Repeat([]byte{' '}, freespace/2), in...)
 		return append(in, bytes.Repeat([]byte{' '}, freespace-(freespace/2))...)
 	}
 	return in
 }
+
+// Justify inserts spaces between the words of a slice of bytes until its
+// "visual" size according to the DefaultProfile reaches the provided limit.
+func Justify(s []byte, sz int) []byte {
+	return DefaultProfile.Justify(s, sz)
+}
+
+// Justify inserts spaces between the words of a slice of bytes until its
+// "visual" size reaches the provided limit. Spaces are evenly distributed, the
+// leftmost gaps between words receiving the remaining ones. A slice of bytes
+// made of only one word is completed with spaces like PadRight does.
+func (pf Profile) Justify(s []byte, sz int) []byte {
+	in := TrimSpace(s)
+
+	freespace := sz - pf.Width(in)
+	if freespace <= 0 {
+		return in
+	}
+
+	// gaps lists the offsets of the end of each sequence of spaces found
+	// between two words.
+	var gaps []int
+	lastSpace := -1
+	_ = ansi.Walk(in, func(advance int, c rune, esc string) error {
+		switch {
+		case len(esc) > 0:
+
+		case unicode.IsSpace(c):
+			lastSpace = advance
+
+		case lastSpace >= 0:
+			gaps = append(gaps, lastSpace)
+			lastSpace = -1
+		}
+		return nil
+	})
+
+	if len(gaps) == 0 {
+		return append(in, bytes.Repeat([]byte{' '}, freespace)...)
+	}
+
+	var justified []byte
+	start := 0
+	for i, gap := range gaps {
+		n := freespace / len(gaps)
+		if i < freespace%len(gaps) {
+			n++
+		}
+		justified = append(justified, in[start:gap]...)
+		justified = append(justified, bytes.Repeat([]byte{' '}, n)...)
+		start = gap
+	}
+
+	return append(justified, in[start:]...)
+}
@@ -16,6 +16,7 @@ func TestPadRight(t *testing.T) {
 		{"\x1b[34mCoucou\x1b[0m", 6, "\x1b[34mCoucou\x1b[0m"},
 		{"Coucou, c'est nous", 9, "Coucou, c'est nous"},
 		{"", 3, "   "},
+		{"🇫🇷", 3, "🇫🇷 "},
 	}
 
 	for _, tc := range testCases {
@@ -71,3 +72,28 @@ func TestPadCenter(t *testing.T) {
 		}
 	}
 }
+
+func TestJustify(t *testing.T) {
+	testCases := []struct {
+		in  string
+		sz  int
+		out string
+	}{
+		{"Coucou", 10, "Coucou    "},
+		{"Coucou, c'est nous", 20, "Coucou,  c'est  nous"},
+		{"Coucou, c'est nous", 21, "Coucou,   c'est  nous"},
+		{" Coucou, c'est nous ", 19, "Coucou,  c'est nous"},
+		{"This \x1b[34mis\x1b[0m a sentence", 20, "This  \x1b[34mis\x1b[0m  a sentence"},
+		{"Coucou  c'est", 15, "Coucou    c'est"},
+		{"日本 語", 8, "日本  語"},
+		{"Coucou, c'est nous", 9, "Coucou, c'est nous"},
+		{"", 3, "   "},
+	}
+
+	for _, tc := range testCases {
+		got := string(Justify([]byte(tc.in), tc.sz))
+		if got != tc.out {
+			t.Errorf("visual Justify failed for '%s' (max %d).\nWant: '%s'\nGot : '%s'\n", tc.in, tc.sz, tc.out, got)
+		}
+	}
+}
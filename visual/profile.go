@@ -0,0 +1,171 @@
+package visual
+
+import (
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	// DefaultEmojiWidth is the width of characters with an emoji presentation
+	// used if not specified by a Profile.
+	DefaultEmojiWidth = 2
+	// DefaultTabWidth is the distance between two tab stops used if not
+	// specified by a Profile.
+	DefaultTabWidth = 8
+)
+
+var (
+	// DefaultProfile is the Profile used by visual's functions that are not
+	// Profile's methods. It is initialized according to the current locale.
+	DefaultProfile = DetectProfile()
+
+	narrowCondition = &runewidth.Condition{StrictEmojiNeutral: true}
+	wideCondition   = &runewidth.Condition{EastAsianWidth: true, StrictEmojiNeutral: true}
+)
+
+// Profile describes how characters are displayed by a terminal, that is to say
+// how many columns they occupy.
+//
+// Profile's zero value describes a terminal that uses a western locale.
+type Profile struct {
+	// AmbiguousWide is true if characters whose East Asian width is
+	// ambiguous (like '§', 'α' or '○') are displayed using two columns,
+	// as it is usually the case for CJK locales.
+	AmbiguousWide bool
+
+	// EmojiWidth is the width of characters or grapheme clusters that have
+	// an emoji presentation. Some terminals or fonts display them using only
+	// one column. If zero, DefaultEmojiWidth is used.
+	EmojiWidth int
+
+	// TabWidth is the distance between two tab stops. If zero,
+	// DefaultTabWidth is used. If negative, tabs are not expanded to the next
+	// tab stop and are considered as zero-width characters.
+	TabWidth int
+
+	// ControlWidth is the width of control characters other than tabs and
+	// end-of-lines. Terminals usually do not display them (zero width) but
+	// some tools display them using a caret notation (like '^G') that is two
+	// columns wide.
+	ControlWidth int
+}
+
+// DetectProfile returns the Profile that corresponds to the current locale as
+// defined by the LC_ALL, LC_CTYPE or LANG environment variables.
+func DetectProfile() Profile {
+	for _, env := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := os.Getenv(env); locale != "" {
+			return ProfileForLocale(locale)
+		}
+	}
+	return Profile{}
+}
+
+// ProfileForLocale returns the Profile that corresponds to the given locale
+// (like "ja_JP.UTF-8" or "fr_FR"). Chinese, Japanese and Korean locales as
+// well as locales using a legacy CJK multi-bytes encoding display ambiguous
+// characters using two columns unless the "@cjk_narrow" modifier is used.
+func ProfileForLocale(locale string) Profile {
+	locale = strings.ToLower(locale)
+
+	if strings.HasSuffix(locale, "@cjk_narrow") {
+		return Profile{}
+	}
+	if i := strings.IndexByte(locale, '@'); i >= 0 {
+		locale = locale[:i]
+	}
+
+	lang, charset := locale, ""
+	if i := strings.IndexByte(locale, '.'); i >= 0 {
+		lang, charset = locale[:i], locale[i+1:]
+	}
+
+	switch charset {
+	case "eucjp", "euc-jp", "sjis", "shift_jis", "euckr", "euc-kr", "euccn", "euc-cn",
+		"gb2312", "gbk", "gb18030", "big5", "big5hkscs", "euctw", "euc-tw":
+		return Profile{AmbiguousWide: true}
+	}
+
+	switch {
+	case strings.HasPrefix(lang, "ja"), strings.HasPrefix(lang, "ko"), strings.HasPrefix(lang, "zh"):
+		return Profile{AmbiguousWide: true}
+	}
+
+	return Profile{}
+}
+
+// Runewidth returns the visual width of a rune.
+// Tabs and end-of-lines are zero-width, tabs' width depending on their
+// position (see Width).
+func (pf Profile) Runewidth(c rune) int {
+	switch {
+	case c == '\t', c == '\n', c == '\r':
+		return 0
+
+	case unicode.IsControl(c):
+		return pf.ControlWidth
+
+	case isEmoji(c):
+		return pf.emojiWidth()
+
+	case pf.AmbiguousWide:
+		return wideCondition.RuneWidth(c)
+	}
+
+	return narrowCondition.RuneWidth(c)
+}
+
+// clusterWidth returns the "visual" width of a grapheme cluster that is
+// displayed at the given column.
+func (pf Profile) clusterWidth(cluster []byte, col int) int {
+	c, sz := utf8.DecodeRune(cluster)
+
+	switch {
+	case c == '\t' && pf.TabWidth >= 0:
+		tw := pf.TabWidth
+		if tw == 0 {
+			tw = DefaultTabWidth
+		}
+		return tw - col%tw
+
+	case sz == len(cluster):
+		return pf.Runewidth(c)
+
+	case isEmoji(c), strings.ContainsRune(string(cluster), '\uFE0F'):
+		return pf.emojiWidth()
+	}
+
+	// Use the width of the first non zero-width rune of the cluster, the
+	// following ones being usually combining marks or modifiers.
+	for _, r := range string(cluster) {
+		if w := pf.Runewidth(r); w > 0 {
+			return w
+		}
+	}
+	return 0
+}
+
+func (pf Profile) emojiWidth() int {
+	if pf.EmojiWidth == 0 {
+		return DefaultEmojiWidth
+	}
+	return pf.EmojiWidth
+}
+
+// isEmoji reports whether a rune is displayed with an emoji presentation by
+// default.
+func isEmoji(c rune) bool {
+	switch {
+	case c >= 0x1F1E6 && c <= 0x1F1FF: // Regional indicators (flags)
+		return true
+
+	case (c >= 0x2300 && c < 0x2E80) || (c >= 0x1F000 && c < 0x20000):
+		return narrowCondition.RuneWidth(c) == 2
+	}
+
+	return false
+}
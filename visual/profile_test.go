@@ -0,0 +1,70 @@
+package visual
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProfileForLocale(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want Profile
+	}{
+		{"C", Profile{}},
+		{"POSIX", Profile{}},
+		{"en_US.UTF-8", Profile{}},
+		{"fr_FR", Profile{}},
+		{"ja_JP.UTF-8", Profile{AmbiguousWide: true}},
+		{"zh_CN.GB18030", Profile{AmbiguousWide: true}},
+		{"ko_KR.UTF-8@cjk_narrow", Profile{}},
+		{"en_US.big5", Profile{AmbiguousWide: true}},
+	}
+
+	for _, tc := range testCases {
+		if got := ProfileForLocale(tc.in); got != tc.want {
+			t.Errorf("Profile for locale %s failed.\nWant: %#v\nGot : %#v", tc.in, tc.want, got)
+		}
+	}
+}
+
+func TestProfileWidth(t *testing.T) {
+	testCases := []struct {
+		profile Profile
+		in      string
+		want    int
+	}{
+		{Profile{}, "§α○", 3},
+		{Profile{AmbiguousWide: true}, "§α○", 6},
+		{Profile{AmbiguousWide: true}, "abc敬具", 7},
+		{Profile{}, "👍🏽!", 3},
+		{Profile{EmojiWidth: 1}, "👍🏽!", 2},
+		{Profile{EmojiWidth: 1}, "🇫🇷❤️", 2},
+		{Profile{}, "a\tb", 9},
+		{Profile{}, "abcdefgh\tb", 17},
+		{Profile{TabWidth: 4}, "a\tb\nab\tc", 10},
+		{Profile{TabWidth: -1}, "a\tb", 2},
+		{Profile{}, "a\x07b", 2},
+		{Profile{ControlWidth: 2}, "a\x07b\n", 4},
+		{Profile{ControlWidth: 2}, "\x1b[1ma\x1b[0m", 1},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.profile.Stringwidth(tc.in); got != tc.want {
+			t.Errorf("Width of %#v using %#v failed.\nWant: %d\nGot : %d", tc.in, tc.profile, tc.want, got)
+		}
+	}
+}
+
+func TestProfileCut(t *testing.T) {
+	pf := Profile{AmbiguousWide: true}
+
+	got := pf.Cut("α β γ δ", 5)
+	want := []string{"α β", " γ ", "δ"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Cut using %#v failed.\nWant: %#v\nGot : %#v", pf, want, got)
+	}
+
+	if got, want := string(pf.PadRight([]byte("α"), 4)), "α  "; got != want {
+		t.Errorf("PadRight using %#v failed.\nWant: %#v\nGot : %#v", pf, want, got)
+	}
+}
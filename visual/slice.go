@@ -0,0 +1,204 @@
+package visual
+
+import (
+	"strings"
+
+	"github.com/pirmd/text/ansi"
+)
+
+// Slice returns the part of s that is displayed between the given "visual"
+// columns (from inclusive, to exclusive).
+// Slice preserves ANSI formatting: the Graphic Rendition in place at 'from'
+// column is restored at the beginning of the returned string and, when
+// needed, the returned string is terminated by an ansi.Reset sequence.
+// Wide characters that do not entirely fit into the columns range are dropped.
+// Columns are measured according to the DefaultProfile.
+func Slice(s string, from, to int) string {
+	return DefaultProfile.Slice(s, from, to)
+}
+
+// Slice returns the part of s that is displayed between the given "visual"
+// columns like visual.Slice does, measuring columns according to the Profile.
+func (pf Profile) Slice(s string, from, to int) string {
+	return pf.slice(s, func(start, end int) bool { return start >= from && end <= to })
+}
+
+// SplitAt splits s at the given "visual" column. Both parts preserve ANSI
+// formatting as Slice does.
+// A wide character that straddles the column goes to the second part.
+// Columns are measured according to the DefaultProfile.
+func SplitAt(s string, col int) (string, string) {
+	return DefaultProfile.SplitAt(s, col)
+}
+
+// SplitAt splits s at the given "visual" column like visual.SplitAt does,
+// measuring columns according to the Profile.
+func (pf Profile) SplitAt(s string, col int) (string, string) {
+	before := pf.slice(s, func(start, end int) bool { return end <= col })
+	after := pf.slice(s, func(start, end int) bool { return end > col })
+	return before, after
+}
+
+// ColumnOf returns the "visual" column at which the byte at the given offset
+// is displayed according to the DefaultProfile.
+func ColumnOf(s string, offset int) int {
+	return DefaultProfile.ColumnOf(s, offset)
+}
+
+// ColumnOf returns the "visual" column at which the byte at the given offset
+// is displayed.
+func (pf Profile) ColumnOf(s string, offset int) (col int) {
+	_ = pf.walkGraphemesString(s, func(n int, cluster string, w int, esc string) error {
+		if n > offset {
+			return ansi.ErrStopWalk
+		}
+		col += w
+		return nil
+	})
+
+	return
+}
+
+// OffsetOf returns the byte offset in s of the character that is displayed at
+// the given "visual" column. It returns len(s) if the column is beyond s's
+// "visual" width. Columns are measured according to the DefaultProfile.
+func OffsetOf(s string, col int) int {
+	return DefaultProfile.OffsetOf(s, col)
+}
+
+// OffsetOf returns the byte offset in s of the character that is displayed at
+// the given "visual" column. It returns len(s) if the column is beyond s's
+// "visual" width.
+func (pf Profile) OffsetOf(s string, col int) int {
+	offset, last, w := len(s), 0, 0
+	_ = pf.walkGraphemesString(s, func(n int, cluster string, cw int, esc string) error {
+		if w += cw; w > col {
+			offset = last
+			return ansi.ErrStopWalk
+		}
+		last = n
+		return nil
+	})
+
+	return offset
+}
+
+// Index returns the byte offset in s of the first instance of substr, ignoring
+// any ANSI escape sequence of s, or -1 if substr is not present.
+func Index(s, substr string) int {
+	plain, offsets := plainText(s)
+
+	i := strings.Index(plain, substr)
+	if i < 0 {
+		return -1
+	}
+	if i == len(plain) {
+		return len(s)
+	}
+	return offsets[i]
+}
+
+// Contains reports whether substr is within s, ignoring any ANSI escape
+// sequence of s.
+func Contains(s, substr string) bool {
+	return Index(s, substr) >= 0
+}
+
+// Replace returns a copy of s with the first n non-overlapping instances of
+// old replaced by new, ignoring any ANSI escape sequence of s. If n < 0, there
+// is no limit on the number of replacements. If old is empty, s is returned
+// unchanged.
+// ANSI escape sequences found in the middle of a replaced instance of old are
+// kept and moved right after the replacement so that the Graphic Rendition
+// surrounding the replaced text is kept intact.
+func Replace(s, old, new string, n int) string {
+	if old == "" || n == 0 {
+		return s
+	}
+
+	plain, offsets := plainText(s)
+
+	var out strings.Builder
+	var last, i int
+	for ; n != 0; n-- {
+		j := strings.Index(plain[i:], old)
+		if j < 0 {
+			break
+		}
+
+		start, end := offsets[i+j], offsets[i+j+len(old)-1]+1
+		out.WriteString(s[last:start])
+		out.WriteString(new)
+		_ = ansi.WalkString(s[start:end], func(n int, c rune, esc string) error {
+			out.WriteString(esc)
+			return nil
+		})
+
+		i, last = i+j+len(old), end
+	}
+	out.WriteString(s[last:])
+
+	return out.String()
+}
+
+// plainText returns s without its ANSI escape sequences as well as, for each
+// byte of the resulting text, its offset in s.
+func plainText(s string) (string, []int) {
+	var plain strings.Builder
+	var offsets []int
+
+	last := 0
+	_ = ansi.WalkString(s, func(n int, c rune, esc string) error {
+		if c > -1 {
+			plain.WriteString(s[last:n])
+			for i := last; i < n; i++ {
+				offsets = append(offsets, i)
+			}
+		}
+		last = n
+		return nil
+	})
+
+	return plain.String(), offsets
+}
+
+// slice returns the contiguous part of s made of the runes for which keep is
+// true, keep being given the "visual" columns where the rune starts and ends.
+func (pf Profile) slice(s string, keep func(start, end int) bool) string {
+	var sliced strings.Builder
+	var sgr ansi.Sequence
+	var col int
+	var started bool
+
+	_ = pf.walkGraphemesString(s, func(n int, cluster string, w int, esc string) error {
+		if len(esc) > 0 {
+			sgr.Combine(esc)
+			if started {
+				sliced.WriteString(esc)
+			}
+			return nil
+		}
+
+		switch {
+		case keep(col, col+w):
+			if !started {
+				sliced.WriteString(sgr.String())
+				started = true
+			}
+			sliced.WriteString(cluster)
+
+		case started:
+			return ansi.ErrStopWalk
+		}
+
+		col += w
+		return nil
+	})
+
+	if !started {
+		return ""
+	}
+
+	sliced.WriteString(sgr.Off())
+	return sliced.String()
+}
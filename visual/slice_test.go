@@ -0,0 +1,107 @@
+package visual
+
+import (
+	"testing"
+)
+
+func TestSlice(t *testing.T) {
+	testCases := []struct {
+		in       string
+		from, to int
+		want     string
+	}{
+		{"Hello world", 0, 5, "Hello"},
+		{"Hello world", 6, 20, "world"},
+		{"Hello world", 20, 30, ""},
+		{"Hello \x1b[34mworld\x1b[0m", 2, 8, "llo \x1b[34mwo\x1b[0m"},
+		{"Hello \x1b[34mworld\x1b[0m", 7, 11, "\x1b[34morld\x1b[0m"},
+		{"\x1b[1mHello \x1b[34mworld\x1b[39m !\x1b[22m", 8, 13, "\x1b[1;34mrld\x1b[39m !\x1b[22m"},
+		{"日本語", 1, 5, "本"},
+	}
+
+	for _, tc := range testCases {
+		if got := Slice(tc.in, tc.from, tc.to); got != tc.want {
+			t.Errorf("Slice failed for %#v[%d:%d].\nWant: %#v\nGot : %#v", tc.in, tc.from, tc.to, tc.want, got)
+		}
+	}
+}
+
+func TestSplitAt(t *testing.T) {
+	testCases := []struct {
+		in         string
+		col        int
+		wantBefore string
+		wantAfter  string
+	}{
+		{"Hello world", 5, "Hello", " world"},
+		{"Hello \x1b[34mworld\x1b[0m", 8, "Hello \x1b[34mwo\x1b[0m", "\x1b[34mrld\x1b[0m"},
+		{"日本語", 3, "日", "本語"},
+		{"Hello", 10, "Hello", ""},
+	}
+
+	for _, tc := range testCases {
+		gotBefore, gotAfter := SplitAt(tc.in, tc.col)
+		if gotBefore != tc.wantBefore || gotAfter != tc.wantAfter {
+			t.Errorf("SplitAt failed for %#v at %d.\nWant: %#v, %#v\nGot : %#v, %#v", tc.in, tc.col, tc.wantBefore, tc.wantAfter, gotBefore, gotAfter)
+		}
+	}
+}
+
+func TestColumnOffset(t *testing.T) {
+	s := "He\x1b[1mllo 日本\x1b[0m!"
+
+	testCases := []struct {
+		offset int
+		col    int
+	}{
+		{0, 0},
+		{6, 2},
+		{10, 6},
+		{13, 8},
+		{len(s) - 1, 10},
+	}
+
+	for _, tc := range testCases {
+		if got := ColumnOf(s, tc.offset); got != tc.col {
+			t.Errorf("ColumnOf failed for %#v at offset %d.\nWant: %d\nGot : %d", s, tc.offset, tc.col, got)
+		}
+		if got := OffsetOf(s, tc.col); got != tc.offset {
+			t.Errorf("OffsetOf failed for %#v at column %d.\nWant: %d\nGot : %d", s, tc.col, tc.offset, got)
+		}
+	}
+
+	if got := OffsetOf(s, 7); got != 10 {
+		t.Errorf("OffsetOf failed for %#v in the middle of a wide rune.\nWant: %d\nGot : %d", s, 10, got)
+	}
+	if got := OffsetOf(s, 20); got != len(s) {
+		t.Errorf("OffsetOf failed for %#v beyond its width.\nWant: %d\nGot : %d", s, len(s), got)
+	}
+}
+
+func TestIndexReplace(t *testing.T) {
+	testCases := []struct {
+		in        string
+		old, new  string
+		wantIndex int
+		want      string
+	}{
+		{"Hello world", "world", "you", 6, "Hello you"},
+		{"Hello \x1b[34mworld\x1b[0m", "world", "you", 11, "Hello \x1b[34myou\x1b[0m"},
+		{"He\x1b[1mllo world\x1b[0m", "Hello", "Bye", 0, "Bye\x1b[1m world\x1b[0m"},
+		{"a-b-c", "-", "+", 1, "a+b+c"},
+		{"Hello", "world", "you", -1, "Hello"},
+	}
+
+	for _, tc := range testCases {
+		if got := Index(tc.in, tc.old); got != tc.wantIndex {
+			t.Errorf("Index failed for %#v in %#v.\nWant: %d\nGot : %d", tc.old, tc.in, tc.wantIndex, got)
+		}
+		if got := Replace(tc.in, tc.old, tc.new, -1); got != tc.want {
+			t.Errorf("Replace failed for %#v in %#v.\nWant: %#v\nGot : %#v", tc.old, tc.in, tc.want, got)
+		}
+	}
+
+	if got, want := Replace("a-b-c", "-", "+", 1), "a+b-c"; got != want {
+		t.Errorf("Replace failed to limit replacements.\nWant: %#v\nGot : %#v", want, got)
+	}
+}
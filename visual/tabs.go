@@ -0,0 +1,35 @@
+package visual
+
+import (
+	"strings"
+)
+
+// ExpandTabs replaces tabs by spaces up to the next tab stop according to the
+// DefaultProfile.
+func ExpandTabs(s string) string {
+	return DefaultProfile.ExpandTabs(s)
+}
+
+// ExpandTabs replaces each tab by as many spaces as needed to reach the next
+// tab stop. Columns are counted from the beginning of each line, ANSI escape
+// sequences being ignored.
+// If Profile's TabWidth is negative, tabs are kept as is.
+func (pf Profile) ExpandTabs(s string) string {
+	if pf.TabWidth < 0 || !strings.ContainsRune(s, '\t') {
+		return s
+	}
+
+	var expanded strings.Builder
+	_ = pf.walkGraphemesString(s, func(n int, cluster string, w int, esc string) error {
+		if cluster == "\t" {
+			expanded.WriteString(strings.Repeat(" ", w))
+			return nil
+		}
+
+		expanded.WriteString(cluster)
+		expanded.WriteString(esc)
+		return nil
+	})
+
+	return expanded.String()
+}
@@ -0,0 +1,28 @@
+package visual
+
+import (
+	"testing"
+)
+
+func TestExpandTabs(t *testing.T) {
+	testCases := []struct {
+		profile Profile
+		in      string
+		want    string
+	}{
+		{Profile{}, "no tab", "no tab"},
+		{Profile{}, "\tHello", "        Hello"},
+		{Profile{}, "a\tb\tc", "a       b       c"},
+		{Profile{TabWidth: 4}, "abc\td\nabcd\te", "abc d\nabcd    e"},
+		{Profile{TabWidth: 4}, "\x1b[1mab\x1b[0m\tc", "\x1b[1mab\x1b[0m  c"},
+		{Profile{TabWidth: 4}, "敬具\tc", "敬具    c"},
+		{Profile{TabWidth: 4}, "ab\x1b[1m\t\x1b[0mc", "ab\x1b[1m  \x1b[0mc"},
+		{Profile{TabWidth: -1}, "a\tb", "a\tb"},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.profile.ExpandTabs(tc.in); got != tc.want {
+			t.Errorf("Expanding tabs of %#v using %#v failed.\nWant: %#v\nGot : %#v", tc.in, tc.profile, tc.want, got)
+		}
+	}
+}
@@ -3,38 +3,56 @@ package visual
 import (
 	"strings"
 
-	"github.com/mattn/go-runewidth"
-
 	"github.com/pirmd/text/ansi"
 )
 
-// Runewidth returns the visual width of a rune.
+// Runewidth returns the visual width of a rune according to the
+// DefaultProfile.
 func Runewidth(c rune) int {
-	return runewidth.RuneWidth(c)
+	return DefaultProfile.Runewidth(c)
+}
+
+// Width returns the "visual" width of a slice of bytes according to the
+// DefaultProfile.
+func Width(p []byte) int {
+	return DefaultProfile.Width(p)
 }
 
 // Width returns the "visual" width of a slice of bytes.
-func Width(p []byte) (w int) {
-	_ = ansi.Walk(p, func(n int, c rune, esc string) error {
-		if len(esc) == 0 {
-			w += Runewidth(c)
-		}
+// Width measures grapheme clusters so that emoji sequences, flags or
+// combining marks are accounted for as they are displayed. Tabs are expanded
+// up to the next tab stop.
+func (pf Profile) Width(p []byte) (w int) {
+	_ = pf.walkGraphemes(p, func(n int, cluster string, cw int, esc string) error {
+		w += cw
 		return nil
 	})
 
 	return
 }
 
-// Stringwidth returns the "visual" width of string.
+// Stringwidth returns the "visual" width of string according to the
+// DefaultProfile.
 func Stringwidth(s string) int {
-	return Width([]byte(s))
+	return DefaultProfile.Stringwidth(s)
 }
 
-// Repeat repeats s until given "visual" size is reached.
+// Stringwidth returns the "visual" width of string.
+func (pf Profile) Stringwidth(s string) int {
+	return pf.Width([]byte(s))
+}
+
+// Repeat repeats s until given "visual" size is reached according to the
+// DefaultProfile.
 func Repeat(s string, sz int) string {
+	return DefaultProfile.Repeat(s, sz)
+}
+
+// Repeat repeats s until given "visual" size is reached.
+func (pf Profile) Repeat(s string, sz int) string {
 	var rs strings.Builder
 
-	i, l := 0, Stringwidth(s)
+	i, l := 0, pf.Stringwidth(s)
 	for i <= sz {
 		rs.WriteString(s)
 		i += l
@@ -44,22 +62,29 @@ func Repeat(s string, sz int) string {
 		return rs.String()
 	}
 
-	return Truncate(rs.String(), sz)
+	return pf.Truncate(rs.String(), sz)
+}
+
+// Truncate truncates the string so that its "visible" length according to the
+// DefaultProfile is lower or equal to the provided limit.
+func Truncate(s string, limit int) string {
+	return DefaultProfile.Truncate(s, limit)
 }
 
 // Truncate truncates the string so that its "visible" length is lower or equal
 // to the provided limit.
 // When needed, Truncate terminates the string by an ansi.Reset sequence
 // to inhibit any visual effects coming from the truncation step.
-func Truncate(s string, limit int) string {
+// Truncate never splits a grapheme cluster.
+func (pf Profile) Truncate(s string, limit int) string {
 	var ts strings.Builder
 	var l int
 	var sgr ansi.Sequence
 
-	_ = ansi.WalkString(s, func(advance int, c rune, esc string) error {
-		if c > -1 {
-			ts.WriteRune(c)
-			l += Runewidth(c)
+	_ = pf.walkGraphemesString(s, func(advance int, cluster string, w int, esc string) error {
+		if len(cluster) > 0 {
+			ts.WriteString(cluster)
+			l += w
 		}
 
 		if len(esc) > 0 {
@@ -85,7 +110,13 @@ func Truncate(s string, limit int) string {
 // pieces.
 // If provided limit is zero or less than zero, Cut only acts at end-of-line.
 func Cut(s string, sz int) (chunks []string) {
-	return cut(s, NewCutter(sz))
+	return DefaultProfile.Cut(s, sz)
+}
+
+// Cut cuts a string like visual.Cut does, measuring "visual" length according
+// to the Profile.
+func (pf Profile) Cut(s string, sz int) (chunks []string) {
+	return cut(s, pf.NewCutter(sz))
 }
 
 // LazyCut cuts a string at end-of-line if the line "visual" length is shorter
@@ -95,7 +126,13 @@ func Cut(s string, sz int) (chunks []string) {
 // fit into the given limit ans is kept as is.
 // If provided limit is zero or less than zero, Cut only acts at end-of-line.
 func LazyCut(s string, sz int) (chunks []string) {
-	return cut(s, NewLazyCutter(sz))
+	return DefaultProfile.LazyCut(s, sz)
+}
+
+// LazyCut cuts a string like visual.LazyCut does, measuring "visual" length
+// according to the Profile.
+func (pf Profile) LazyCut(s string, sz int) (chunks []string) {
+	return cut(s, pf.NewLazyCutter(sz))
 }
 
 func cut(s string, cutr *Cutter) (chunks []string) {
@@ -3,6 +3,7 @@ package visual
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestStringwidth(t *testing.T) {
@@ -14,6 +15,11 @@ func TestStringwidth(t *testing.T) {
 		{"敬具", 4},
 		{"a\x00bc", 3},
 		{"\x1b[35ma\x00bc\x1b[0m", 3},
+		{"👍🏽", 2},
+		{"👨\u200d👩\u200d👧", 2},
+		{"🇫🇷", 2},
+		{"e\u0301te\u0301", 3},
+		{"\x1b[1m👨\u200d👩\u200d👧\x1b[0m!", 3},
 	}
 
 	for _, tc := range testCases {
@@ -34,6 +40,8 @@ func TestTruncate(t *testing.T) {
 		{"This is a long sentence", 9, "This is a"},
 		{"This \x1b[34mis\x1b[0m a long sentence in color", 9, "This \x1b[34mis\x1b[0m a"},
 		{"This \x1b[34mis a long sentence in\x1b[0m color", 9, "This \x1b[34mis a\x1b[0m"},
+		{"Hi 👨\u200d👩\u200d👧 there", 5, "Hi 👨\u200d👩\u200d👧"},
+		{"e\u0301te\u0301", 2, "e\u0301t"},
 	}
 
 	for _, tc := range testCases {
@@ -84,6 +92,9 @@ func TestCut(t *testing.T) {
 		{"This \x1b[34mis a long sentence\n\x1b[0m", 10, []string{"This \x1b[34mis a ", "long ", "sentence\n\x1b[0m"}},
 		{"Supercalifragilisticexpialidocious\nChim Chim Cher-ee", 10, []string{"Supercalif", "ragilistic", "expialidoc", "ious\n", "Chim Chim ", "Cher-ee"}},
 		{"Supercalifragilisticexpialidocious\nChim Chim Cher-ee", -1, []string{"Supercalifragilisticexpialidocious\n", "Chim Chim Cher-ee"}},
+		{"👍🏽👍🏽👍🏽", 4, []string{"👍🏽👍🏽", "👍🏽"}},
+		{"de\u0301ja\u0300", 2, []string{"de\u0301", "ja\u0300"}},
+		{"Hi\r\nthere", 10, []string{"Hi\r\n", "there"}},
 		{
 			"description: This edition contains Alice's Adventures in Wonderland. Tweedledum and Tweedledee, the Mad Hatter, the Cheshire Cat, the Red Queen and the White Rabbit all make their appearances, and are now familiar figures in writing, conversation and idiom.\nauthor: Lewis Caroll",
 			58,
@@ -109,6 +120,34 @@ func TestCut(t *testing.T) {
 	}
 }
 
+func TestCutNarrow(t *testing.T) {
+	testCases := []struct {
+		in  string
+		sz  int
+		out []string
+	}{
+		{"\t", 4, []string{"\t"}},
+		{"\t", 1, []string{"\t"}},
+		{"a\tb", 4, []string{"a\t", "b"}},
+		{"ab\tc", 2, []string{"ab\t", "c"}},
+		{"日本", 1, []string{"日", "本"}},
+	}
+
+	for _, tc := range testCases {
+		done := make(chan []string, 1)
+		go func() { done <- Profile{}.Cut(tc.in, tc.sz) }()
+
+		select {
+		case got := <-done:
+			if !reflect.DeepEqual(got, tc.out) {
+				t.Errorf("Wrap failed for %#v.\nWanted:\n%#v\nGot   :\n%#v\n", tc.in, tc.out, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Wrap of %#v to %d does not terminate", tc.in, tc.sz)
+		}
+	}
+}
+
 func TestLazyCut(t *testing.T) {
 	testCases := []struct {
 		in  string